@@ -0,0 +1,267 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// File tracks a project's action items, at the project root
+	File = "actions.yaml"
+	// DateFormat is how due dates are written
+	DateFormat = "2006-01-02"
+)
+
+// Statuses of an action item
+const (
+	StatusOpen = "open"
+	StatusDone = "done"
+)
+
+// duplicateThreshold is how much of their wording two descriptions must
+// share to be the same action
+const duplicateThreshold = 0.6
+
+// Item is a follow-up from a call, email or note
+type Item struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Owner       string `yaml:"owner,omitempty"`
+	// Due is a date in DateFormat
+	Due       string    `yaml:"due,omitempty"`
+	Status    string    `yaml:"status"`
+	Sources   []Source  `yaml:"sources,omitempty"`
+	Created   time.Time `yaml:"created"`
+	Completed time.Time `yaml:"completed,omitempty"`
+}
+
+// Source is where an action item was found
+type Source struct {
+	// File is relative to the project root
+	File string `yaml:"file"`
+	// Ref locates the action in the file: a timestamp, speaker, heading or
+	// short quote
+	Ref string `yaml:"ref,omitempty"`
+}
+
+// List is a project's action items
+type List struct {
+	Items []Item `yaml:"actions"`
+}
+
+// Load reads a project's action items; a missing file is an empty list
+func Load(m *project.Manifest) (*List, error) {
+	content, err := os.ReadFile(m.Path(File))
+	if os.IsNotExist(err) {
+		return &List{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", File, err)
+	}
+	l := &List{}
+	if err := yaml.Unmarshal(content, l); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", File, err)
+	}
+	return l, nil
+}
+
+// Exists reports whether a project tracks action items
+func Exists(root string) bool {
+	_, err := os.Stat(filepath.Join(root, File))
+	return err == nil
+}
+
+// Save writes the action items to the project root
+func (l *List) Save(m *project.Manifest) error {
+	content, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", File, err)
+	}
+	if err := os.WriteFile(m.Path(File), content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", File, err)
+	}
+	return nil
+}
+
+// Merge adds newly extracted items, skipping those already tracked. A
+// duplicate gains the new source and any owner or due date it lacked.
+func (l *List) Merge(items []Item) (added, duplicates int) {
+	for _, item := range items {
+		if existing := l.duplicateOf(item); existing != nil {
+			existing.merge(item)
+			duplicates++
+			continue
+		}
+		item.ID = l.nextID()
+		if item.Status == "" {
+			item.Status = StatusOpen
+		}
+		l.Items = append(l.Items, item)
+		added++
+	}
+	return added, duplicates
+}
+
+func (l *List) duplicateOf(item Item) *Item {
+	words := wordSet(item.Description)
+	for i := range l.Items {
+		existing := &l.Items[i]
+		if existing.Owner != "" && item.Owner != "" && !sameOwner(existing.Owner, item.Owner) {
+			continue
+		}
+		if similarity(words, wordSet(existing.Description)) >= duplicateThreshold {
+			return existing
+		}
+	}
+	return nil
+}
+
+func (i *Item) merge(other Item) {
+	if i.Owner == "" {
+		i.Owner = other.Owner
+	}
+	if i.Due == "" {
+		i.Due = other.Due
+	}
+	for _, source := range other.Sources {
+		known := false
+		for _, s := range i.Sources {
+			if s.File == source.File {
+				known = true
+				break
+			}
+		}
+		if !known {
+			i.Sources = append(i.Sources, source)
+		}
+	}
+}
+
+func (l *List) nextID() string {
+	highest := 0
+	for _, item := range l.Items {
+		if n, err := strconv.Atoi(strings.TrimPrefix(item.ID, "A")); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return fmt.Sprintf("A%d", highest+1)
+}
+
+// Find returns the item with the given ID
+func (l *List) Find(id string) (*Item, error) {
+	for i := range l.Items {
+		if strings.EqualFold(l.Items[i].ID, id) {
+			return &l.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no action %s", id)
+}
+
+// Done marks an item complete
+func (l *List) Done(id string, at time.Time) (*Item, error) {
+	item, err := l.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == StatusDone {
+		return nil, fmt.Errorf("action %s is already done", item.ID)
+	}
+	item.Status = StatusDone
+	item.Completed = at.UTC().Truncate(time.Second)
+	return item, nil
+}
+
+// Open returns the open items, soonest due first and undated last
+func (l *List) Open() []Item {
+	var open []Item
+	for _, item := range l.Items {
+		if item.Status != StatusDone {
+			open = append(open, item)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		a, b := open[i].Due, open[j].Due
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		return a < b
+	})
+	return open
+}
+
+// Overdue returns the open items due before today
+func (l *List) Overdue(today time.Time) []Item {
+	var overdue []Item
+	for _, item := range l.Open() {
+		if item.IsOverdue(today) {
+			overdue = append(overdue, item)
+		}
+	}
+	return overdue
+}
+
+// Completed returns the done items, most recently completed first
+func (l *List) Completed() []Item {
+	var done []Item
+	for _, item := range l.Items {
+		if item.Status == StatusDone {
+			done = append(done, item)
+		}
+	}
+	sort.SliceStable(done, func(i, j int) bool {
+		return done[i].Completed.After(done[j].Completed)
+	})
+	return done
+}
+
+// IsOverdue reports whether an open item was due before today
+func (i Item) IsOverdue(today time.Time) bool {
+	return i.Status != StatusDone && i.Due != "" && i.Due < today.Format(DateFormat)
+}
+
+func sameOwner(a, b string) bool {
+	a, b = strings.ToLower(strings.TrimSpace(a)), strings.ToLower(strings.TrimSpace(b))
+	// "Jane" and "Jane Doe" are the same person
+	return a == b || strings.HasPrefix(a, b+" ") || strings.HasPrefix(b, a+" ")
+}
+
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "will": true, "to": true,
+	"a": true, "an": true, "of": true, "on": true, "by": true, "in": true, "our": true,
+	"their": true, "them": true,
+}
+
+func wordSet(s string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !stopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// similarity is the share of words two descriptions have in common
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
@@ -0,0 +1,232 @@
+package actions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+func TestDuplicateOf(t *testing.T) {
+	existing := Item{ID: "A1", Description: "Send the pricing proposal to Acme", Owner: "Jane Doe"}
+	tests := []struct {
+		name string
+		item Item
+		want bool
+	}{
+		{"same wording", Item{Description: "Send the pricing proposal to Acme"}, true},
+		{"near duplicate", Item{Description: "Send pricing proposal to Acme by Friday"}, true},
+		{"different action", Item{Description: "Book the demo environment"}, false},
+		{"first name only", Item{Description: "Send the pricing proposal to Acme", Owner: "jane"}, true},
+		{"other owner", Item{Description: "Send the pricing proposal to Acme", Owner: "John Smith"}, false},
+		{"owner prefix without space", Item{Description: "Send the pricing proposal to Acme", Owner: "Jan"}, false},
+		{"no owner", Item{Description: "Send the pricing proposal to Acme", Owner: ""}, true},
+	}
+	for _, tt := range tests {
+		l := &List{Items: []Item{existing}}
+		if got := l.duplicateOf(tt.item) != nil; got != tt.want {
+			t.Errorf("%s: duplicateOf(%q, %q) = %v, want %v", tt.name, tt.item.Description, tt.item.Owner, got, tt.want)
+		}
+	}
+}
+
+func TestSameOwner(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Jane", "Jane Doe", true},
+		{" jane doe ", "Jane Doe", true},
+		{"Jane Doe", "Jane", true},
+		{"Jane", "Janet", false},
+		{"Jane Doe", "John Doe", false},
+	}
+	for _, tt := range tests {
+		if got := sameOwner(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameOwner(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := similarity(wordSet("the demo"), wordSet("")); got != 0 {
+		t.Errorf("similarity with an empty description = %v", got)
+	}
+	// Stop words and punctuation are ignored
+	if got := similarity(wordSet("Book the demo!"), wordSet("book demo")); got != 1 {
+		t.Errorf("similarity = %v, want 1", got)
+	}
+	if got := similarity(wordSet("book demo"), wordSet("book workshop")); got != 1.0/3 {
+		t.Errorf("similarity = %v, want 1/3", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	l := &List{Items: []Item{
+		{ID: "A7", Description: "Send the pricing proposal", Status: StatusOpen,
+			Sources: []Source{{File: "calls/kickoff.md"}}},
+	}}
+
+	added, duplicates := l.Merge([]Item{
+		{Description: "Send pricing proposal", Owner: "Jane", Due: "2025-02-01",
+			Sources: []Source{{File: "emails/pricing.md", Ref: "Jane"}, {File: "calls/kickoff.md"}}},
+		{Description: "Book the demo environment", Sources: []Source{{File: "emails/pricing.md"}}},
+		{Description: "Share the security questionnaire", Status: StatusDone},
+	})
+	if added != 2 || duplicates != 1 {
+		t.Fatalf("Merge = %d added, %d duplicates, want 2 and 1", added, duplicates)
+	}
+
+	merged := l.Items[0]
+	if merged.Owner != "Jane" || merged.Due != "2025-02-01" {
+		t.Errorf("duplicate did not gain the owner and due date: %+v", merged)
+	}
+	if len(merged.Sources) != 2 || merged.Sources[1].File != "emails/pricing.md" {
+		t.Errorf("duplicate sources = %+v", merged.Sources)
+	}
+
+	if l.Items[1].ID != "A8" || l.Items[1].Status != StatusOpen {
+		t.Errorf("new item = %+v, want A8 and open", l.Items[1])
+	}
+	if l.Items[2].ID != "A9" || l.Items[2].Status != StatusDone {
+		t.Errorf("new item = %+v, want A9 and done kept", l.Items[2])
+	}
+
+	// A duplicate keeps the owner and due date it already had
+	l.Merge([]Item{{Description: "Send the pricing proposal", Owner: "Jane Doe", Due: "2025-03-01"}})
+	if l.Items[0].Owner != "Jane" || l.Items[0].Due != "2025-02-01" {
+		t.Errorf("duplicate overwrote its owner or due date: %+v", l.Items[0])
+	}
+}
+
+func TestNextID(t *testing.T) {
+	l := &List{}
+	if got := l.nextID(); got != "A1" {
+		t.Errorf("nextID of an empty list = %s", got)
+	}
+	l.Items = []Item{{ID: "A2"}, {ID: "A10"}, {ID: "custom"}, {ID: "A3"}}
+	if got := l.nextID(); got != "A11" {
+		t.Errorf("nextID = %s, want A11", got)
+	}
+}
+
+func TestOpenAndOverdue(t *testing.T) {
+	l := &List{Items: []Item{
+		{ID: "A1", Status: StatusOpen},
+		{ID: "A2", Status: StatusOpen, Due: "2025-03-01"},
+		{ID: "A3", Status: StatusDone, Due: "2025-01-01"},
+		{ID: "A4", Status: StatusOpen, Due: "2025-01-15"},
+		{ID: "A5", Status: StatusOpen, Due: "2025-02-01"},
+	}}
+
+	var ids []string
+	for _, item := range l.Open() {
+		ids = append(ids, item.ID)
+	}
+	if got := strings.Join(ids, " "); got != "A4 A5 A2 A1" {
+		t.Errorf("Open = %s, want soonest due first and undated last", got)
+	}
+
+	today := time.Date(2025, 2, 1, 9, 0, 0, 0, time.Local)
+	ids = nil
+	for _, item := range l.Overdue(today) {
+		ids = append(ids, item.ID)
+	}
+	if got := strings.Join(ids, " "); got != "A4" {
+		t.Errorf("Overdue = %s, want A4 (due today is not overdue, done items never are)", got)
+	}
+}
+
+func TestDone(t *testing.T) {
+	l := &List{Items: []Item{{ID: "A1", Status: StatusOpen}}}
+	if _, err := l.Done("a1", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Done("A1", time.Now()); err == nil {
+		t.Error("Done accepted an action that is already done")
+	}
+	if _, err := l.Done("A2", time.Now()); err == nil {
+		t.Error("Done accepted an unknown action")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	m := project.NewManifest(t.TempDir(), "proj")
+	l, err := Load(m)
+	if err != nil || len(l.Items) != 0 {
+		t.Fatalf("Load without %s = %+v, %v", File, l, err)
+	}
+	l.Merge([]Item{{Description: "Book the demo", Due: "2025-02-01", Sources: []Source{{File: "notes/a.md"}}}})
+	if err := l.Save(m); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Items) != 1 || loaded.Items[0].ID != "A1" || loaded.Items[0].Sources[0].File != "notes/a.md" {
+		t.Errorf("loaded %+v", loaded.Items)
+	}
+}
+
+// scriptedProvider returns a fixed structured response
+type scriptedProvider struct {
+	provider.FakeProvider
+	response string
+}
+
+func (p *scriptedProvider) ExecuteStructured(promptContent, userInput, schemaName string, schema any) (string, error) {
+	return p.response, nil
+}
+
+// plainProvider hides the fake provider's structured output
+type plainProvider struct {
+	provider.Provider
+}
+
+func TestExtract(t *testing.T) {
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+
+	items, err := Extract(&provider.FakeProvider{}, "notes/a.md", "Nothing to do.", date)
+	if err != nil || len(items) != 0 {
+		t.Fatalf("Extract with the fake provider = %+v, %v", items, err)
+	}
+
+	p := &scriptedProvider{response: `{"actions": [
+		{"description": " Send the pricing proposal ", "owner": "Jane", "due": "2025-01-17", "source_ref": "00:14:32 Jane"},
+		{"description": "Book the demo", "owner": "", "due": "next Friday", "source_ref": ""},
+		{"description": "  ", "owner": "John", "due": "", "source_ref": ""}
+	]}`}
+	items, err = Extract(p, "calls/kickoff.md", "transcript", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Extract = %+v, want 2 items", items)
+	}
+	first := items[0]
+	if first.Description != "Send the pricing proposal" || first.Owner != "Jane" || first.Due != "2025-01-17" ||
+		first.Status != StatusOpen || first.Sources[0] != (Source{File: "calls/kickoff.md", Ref: "00:14:32 Jane"}) {
+		t.Errorf("first item = %+v", first)
+	}
+	if items[1].Due != "" {
+		t.Errorf("unparseable due date kept: %q", items[1].Due)
+	}
+
+	p.response = "not json"
+	if _, err := Extract(p, "calls/kickoff.md", "transcript", date); err == nil {
+		t.Error("Extract accepted an invalid response")
+	}
+	if _, err := Extract(plainProvider{&provider.FakeProvider{}}, "notes/a.md", "text", date); err == nil {
+		t.Error("Extract accepted a provider without structured output")
+	}
+}
+
+func TestDocumentDate(t *testing.T) {
+	got := DocumentDate("/nowhere/2025-01-15_Acme_kickoff.md")
+	if got.Format(DateFormat) != "2025-01-15" {
+		t.Errorf("DocumentDate = %s, want the date in the file name", got)
+	}
+}
@@ -0,0 +1,112 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+// schema is the structured output the model returns
+var schema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"actions": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"description": map[string]any{"type": "string", "description": "What needs to be done, as an imperative sentence"},
+					"owner":       map[string]any{"type": "string", "description": "Who committed to it, or empty when nobody did"},
+					"due":         map[string]any{"type": "string", "description": "Due date as YYYY-MM-DD, or empty when none was given"},
+					"source_ref":  map[string]any{"type": "string", "description": "Where it was said: a timestamp, speaker, heading or short quote"},
+				},
+				"required":             []string{"description", "owner", "due", "source_ref"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"actions"},
+	"additionalProperties": false,
+}
+
+const extractPrompt = `You extract action items from customer engagement material (call transcripts, emails and notes) for a ServiceNow solution consultant.
+
+List every concrete follow-up someone committed to or was asked to do. Skip general discussion, past work and vague intentions.
+- description: what needs to be done, as a short imperative sentence
+- owner: the person or team responsible, as named in the material; empty when nobody was named
+- due: the due date as YYYY-MM-DD. Resolve relative dates ("by Friday", "next week") against the document date. Empty when no date was given.
+- source_ref: where the action was stated, e.g. "00:14:32 Jane Doe", a heading or a short quote
+
+The document date is %s.`
+
+// Extract asks the model for the action items in a file's content. The
+// source is the file's project-relative path and date is when the material
+// was written, for resolving relative due dates.
+func Extract(p provider.Provider, source, content string, date time.Time) ([]Item, error) {
+	sp, ok := p.(provider.StructuredProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support structured output", p.Name())
+	}
+
+	response, err := sp.ExecuteStructured(fmt.Sprintf(extractPrompt, date.Format("Monday, 2006-01-02")),
+		fmt.Sprintf("Source: %s\n\n%s", source, content), "action_items", schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Actions []struct {
+			Description string `json:"description"`
+			Owner       string `json:"owner"`
+			Due         string `json:"due"`
+			SourceRef   string `json:"source_ref"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return nil, fmt.Errorf("model returned invalid action items: %w", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var items []Item
+	for _, a := range result.Actions {
+		description := strings.TrimSpace(a.Description)
+		if description == "" {
+			continue
+		}
+		due := strings.TrimSpace(a.Due)
+		if _, err := time.Parse(DateFormat, due); err != nil {
+			due = ""
+		}
+		items = append(items, Item{
+			Description: description,
+			Owner:       strings.TrimSpace(a.Owner),
+			Due:         due,
+			Status:      StatusOpen,
+			Sources:     []Source{{File: source, Ref: strings.TrimSpace(a.SourceRef)}},
+			Created:     now,
+		})
+	}
+	return items, nil
+}
+
+var datePrefix = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})`)
+
+// DocumentDate is when a file was written: the date its name starts with, as
+// given by "now-sc ingest", or its modification time
+func DocumentDate(path string) time.Time {
+	if m := datePrefix.FindStringSubmatch(filepath.Base(path)); m != nil {
+		if date, err := time.ParseInLocation(DateFormat, m[1], time.Local); err == nil {
+			return date
+		}
+	}
+	if info, err := os.Stat(path); err == nil {
+		return info.ModTime()
+	}
+	return time.Now()
+}
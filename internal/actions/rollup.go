@@ -0,0 +1,110 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+const (
+	// RollupName is the Markdown summary of a project's action items, kept
+	// in the layout's overview folder
+	RollupName = "Action_Items.md"
+	// rollupDone caps the completed items listed in the rollup
+	rollupDone = 20
+)
+
+// RollupFile returns the Markdown rollup, relative to the project root
+func RollupFile(layout *project.Layout) string {
+	return filepath.ToSlash(filepath.Join(layout.RoleDir(project.RoleOverview), RollupName))
+}
+
+// Rollup renders the action items as Markdown: overdue, open, then recently
+// completed. Sources are linked relative to dir, the rollup's folder.
+func (l *List) Rollup(dir string, today time.Time) string {
+	var b strings.Builder
+	b.WriteString("# Action Items\n\n")
+	fmt.Fprintf(&b, "_Updated %s from %s. Manage with \"now-sc actions\"; edits here are overwritten._\n", today.Format(DateFormat), File)
+
+	overdue := l.Overdue(today)
+	var upcoming []Item
+	for _, item := range l.Open() {
+		if !item.IsOverdue(today) {
+			upcoming = append(upcoming, item)
+		}
+	}
+	done := l.Completed()
+
+	section := func(title string, items []Item, withCompleted bool) {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", title, len(items))
+		if len(items) == 0 {
+			b.WriteString("None.\n")
+			return
+		}
+		if withCompleted {
+			b.WriteString("| ID | Completed | Owner | Action | Source |\n|---|---|---|---|---|\n")
+		} else {
+			b.WriteString("| ID | Due | Owner | Action | Source |\n|---|---|---|---|---|\n")
+		}
+		for _, item := range items {
+			date := item.Due
+			if withCompleted {
+				date = item.Completed.Local().Format(DateFormat)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", item.ID, orDash(date), orDash(cell(item.Owner)),
+				cell(item.Description), sourceLinks(dir, item.Sources))
+		}
+	}
+	section("Overdue", overdue, false)
+	section("Open", upcoming, false)
+	if len(done) > rollupDone {
+		done = done[:rollupDone]
+	}
+	section("Recently Completed", done, true)
+	return b.String()
+}
+
+// WriteRollup writes the Markdown rollup into the project
+func (l *List) WriteRollup(m *project.Manifest, layout *project.Layout, today time.Time) error {
+	file := RollupFile(layout)
+	path := m.Path(filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(file), err)
+	}
+	if err := os.WriteFile(path, []byte(l.Rollup(filepath.Dir(file), today)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+// sourceLinks links each source relative to the rollup's folder
+func sourceLinks(dir string, sources []Source) string {
+	var links []string
+	for _, s := range sources {
+		target, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(s.File))
+		if err != nil {
+			target = s.File
+		}
+		link := fmt.Sprintf("[%s](%s)", cell(filepath.Base(s.File)), strings.ReplaceAll(filepath.ToSlash(target), " ", "%20"))
+		if s.Ref != "" {
+			link += " " + cell(s.Ref)
+		}
+		links = append(links, link)
+	}
+	return orDash(strings.Join(links, "<br>"))
+}
+
+func cell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
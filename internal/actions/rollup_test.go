@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+func TestRollup(t *testing.T) {
+	today := time.Date(2025, 2, 1, 9, 0, 0, 0, time.Local)
+	l := &List{Items: []Item{
+		{ID: "A1", Description: "Send the pricing | proposal", Owner: "Jane", Due: "2025-01-20", Status: StatusOpen,
+			Sources: []Source{{File: "00_Inbox/calls/Acme kickoff.md", Ref: "00:14"}}},
+		{ID: "A2", Description: "Book the demo", Status: StatusOpen},
+		{ID: "A3", Description: "Share the questionnaire", Status: StatusDone, Completed: today.Add(-time.Hour)},
+	}}
+
+	rollup := l.Rollup("99_Assets/Project_Overview", today)
+	for _, want := range []string{
+		"## Overdue (1)",
+		`| A1 | 2025-01-20 | Jane | Send the pricing \| proposal | [Acme kickoff.md](../../00_Inbox/calls/Acme%20kickoff.md) 00:14 |`,
+		"## Open (1)",
+		"| A2 | - | - | Book the demo | - |",
+		"## Recently Completed (1)",
+		"| A3 | 2025-02-01 |",
+	} {
+		if !strings.Contains(rollup, want) {
+			t.Errorf("rollup is missing %q:\n%s", want, rollup)
+		}
+	}
+}
+
+func TestWriteRollupFollowsLayout(t *testing.T) {
+	layout, err := project.ParseLayout([]byte(`version: 1
+directories:
+  - name: Customers
+    customers: true
+  - name: 10_PromptTemplates
+  - name: Status
+    role: overview
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := project.NewManifest(t.TempDir(), "proj")
+	l := &List{Items: []Item{{ID: "A1", Description: "Book the demo", Status: StatusOpen,
+		Sources: []Source{{File: "notes/a.md"}}}}}
+	if err := l.WriteRollup(m, layout, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(m.Path("Status", RollupName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "(../notes/a.md)") {
+		t.Errorf("source not linked relative to the rollup:\n%s", content)
+	}
+}
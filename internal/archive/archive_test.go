@@ -0,0 +1,123 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTarGzRoundTripKeepsSymlinks(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "proj")
+	if err := os.MkdirAll(filepath.Join(src, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "docs", "notes.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"latest.md": "docs/notes.md",
+		"shared":    "../shared-drive",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(src, name)); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+
+	dest := filepath.Join(t.TempDir(), "proj.tar.gz")
+	if err := WriteTarGz(dest, src); err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+	if err := ExtractTarGz(dest, out); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "proj", "docs", "notes.md"))
+	if err != nil || string(data) != "notes" {
+		t.Errorf("notes.md: got %q, %v", data, err)
+	}
+	for name, want := range links {
+		got, err := os.Readlink(filepath.Join(out, "proj", name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if got != want {
+			t.Errorf("%s: got link to %s, want %s", name, got, want)
+		}
+	}
+}
+
+// writeTar writes a tarball with the given headers; regular files get their
+// name as content
+func writeTar(t *testing.T, headers []tar.Header) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, h := range headers {
+		h.Mode = 0644
+		if h.Typeflag == tar.TypeReg {
+			h.Size = int64(len(h.Name))
+		}
+		if err := tw.WriteHeader(&h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(h.Name)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExtractTarGzRejectsUnsafeEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []tar.Header
+	}{
+		{"parent path", []tar.Header{{Name: "../evil.txt", Typeflag: tar.TypeReg}}},
+		{"absolute path", []tar.Header{{Name: "/tmp/evil.txt", Typeflag: tar.TypeReg}}},
+		{"file through symlink", []tar.Header{
+			{Name: "proj/out", Typeflag: tar.TypeSymlink, Linkname: "OUTSIDE"},
+			{Name: "proj/out/evil.txt", Typeflag: tar.TypeReg},
+		}},
+		{"symlink through symlink", []tar.Header{
+			{Name: "proj/out", Typeflag: tar.TypeSymlink, Linkname: "OUTSIDE"},
+			{Name: "proj/out/link", Typeflag: tar.TypeSymlink, Linkname: "x"},
+		}},
+		{"overwrite symlink", []tar.Header{
+			{Name: "proj/file", Typeflag: tar.TypeSymlink, Linkname: "OUTSIDE/evil.txt"},
+			{Name: "proj/file", Typeflag: tar.TypeReg},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outside := t.TempDir()
+			for i := range tt.headers {
+				tt.headers[i].Linkname = strings.ReplaceAll(tt.headers[i].Linkname, "OUTSIDE", outside)
+			}
+			if err := ExtractTarGz(writeTar(t, tt.headers), t.TempDir()); err == nil {
+				t.Error("expected an error")
+			}
+			entries, _ := os.ReadDir(outside)
+			if len(entries) > 0 {
+				t.Errorf("wrote outside the destination: %v", entries)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Supported archive formats
+const (
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip"
+)
+
+// File is a file to write into an archive. Content is used when set,
+// otherwise the file is read from Source.
+type File struct {
+	Name    string
+	Source  string
+	Content []byte
+	ModTime time.Time
+}
+
+// FormatOf returns the archive format implied by a file name
+func FormatOf(name string) (string, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return FormatTarGz, nil
+	case strings.HasSuffix(lower, ".zip"):
+		return FormatZip, nil
+	}
+	return "", fmt.Errorf("unsupported archive %s (expected .zip or .tar.gz)", name)
+}
+
+// Write creates an archive at dest holding files, in order
+func Write(dest, format string, files []File) error {
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer out.Close()
+
+	switch format {
+	case FormatZip:
+		err = writeZip(out, files)
+	case FormatTarGz:
+		err = writeTarGz(out, files)
+	default:
+		err = fmt.Errorf("unsupported archive format %q", format)
+	}
+	if err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+	return out.Close()
+}
+
+func writeZip(w io.Writer, files []File) error {
+	zw := zip.NewWriter(w)
+	for _, file := range files {
+		header := &zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: file.ModTime}
+		header.SetMode(0644)
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(fw, file); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeTarGz(w io.Writer, files []File) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		size := int64(len(file.Content))
+		if file.Content == nil {
+			info, err := os.Stat(file.Source)
+			if err != nil {
+				return err
+			}
+			size = info.Size()
+		}
+
+		header := &tar.Header{Name: file.Name, Mode: 0644, Size: size, ModTime: file.ModTime, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if err := copyFile(tw, file); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func copyFile(w io.Writer, file File) error {
+	if file.Content != nil {
+		_, err := w.Write(file.Content)
+		return err
+	}
+
+	f, err := os.Open(file.Source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// Walk calls fn for every regular file in a .zip or .tar.gz archive, in
+// archive order
+func Walk(src string, fn func(name string, r io.Reader) error) error {
+	format, err := FormatOf(src)
+	if err != nil {
+		return err
+	}
+
+	if format == FormatZip {
+		zr, err := zip.OpenReader(src)
+		if err != nil {
+			return fmt.Errorf("failed to open archive %s: %w", src, err)
+		}
+		defer zr.Close()
+
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			err = fn(f.Name, rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("failed to read archive %s: %w", src, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive %s: %w", src, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// Extract writes every file of a .zip or .tar.gz archive into destDir.
+// Entries that would escape destDir are rejected.
+func Extract(src, destDir string) error {
+	return Walk(src, func(name string, r io.Reader) error {
+		target, err := safeJoin(destDir, name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := writeFile(target, r, 0644); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+		return nil
+	})
+}
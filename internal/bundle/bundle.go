@@ -0,0 +1,375 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/archive"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ManifestFile is the export manifest at the top of every bundle
+	ManifestFile = "now-sc-export.yaml"
+	// FormatVersion is the bundle layout version written by this CLI
+	FormatVersion = 1
+)
+
+// ExportsDir is where bundles are written when no output path is given,
+// relative to the project root
+const ExportsDir = project.MetaDir + "/exports"
+
+// DefaultExcludes are never exported: secrets, git internals, earlier
+// bundles and the redaction reports of sanitized exports, which hold the
+// original values
+var DefaultExcludes = []string{".env", ".git/", ExportsDir + "/", "*.redactions.md"}
+
+// Manifest describes a bundle and checksums every file in it
+type Manifest struct {
+	FormatVersion int       `yaml:"format_version"`
+	Project       string    `yaml:"project"`
+	Customers     []string  `yaml:"customers,omitempty"`
+	Stage         string    `yaml:"stage,omitempty"`
+	Exported      time.Time `yaml:"exported"`
+	ExportedBy    string    `yaml:"exported_by,omitempty"`
+	CLIVersion    string    `yaml:"cli_version"`
+	// Root is the folder inside the bundle holding the project
+	Root     string   `yaml:"root"`
+	Excludes []string `yaml:"excludes"`
+	Files    []File   `yaml:"files"`
+}
+
+// File is a checksummed file in a bundle, relative to the project root
+type File struct {
+	Path   string `yaml:"path"`
+	Size   int64  `yaml:"size"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// Options control an export
+type Options struct {
+	// Excludes are extra rules: "dir/" excludes a folder, other patterns
+	// match the project-relative path or the file name
+	Excludes []string
+	// Redactor rewrites names, paths and content before they are archived,
+	// e.g. to strip customer data; nil exports the project as is
+	Redactor Redactor
+}
+
+// Redactor rewrites what goes into a bundle
+type Redactor interface {
+	// Text rewrites a name recorded in the export manifest
+	Text(s string) string
+	// Path rewrites a project-relative path
+	Path(rel string) string
+	// File rewrites a file's content, or returns false to leave it out
+	File(rel string, content []byte) ([]byte, bool)
+}
+
+// Export writes the project to dest as a .zip or .tar.gz bundle, chosen by
+// its extension, and returns the bundle's manifest
+func Export(m *project.Manifest, dest string, opts Options) (*Manifest, error) {
+	format, err := archive.FormatOf(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	excludes := append(append([]string{}, DefaultExcludes...), opts.Excludes...)
+	destAbs, _ := filepath.Abs(dest)
+
+	bm := &Manifest{
+		FormatVersion: FormatVersion,
+		Project:       m.Project,
+		Customers:     m.CustomerNames(),
+		Stage:         m.Stage,
+		Exported:      time.Now().UTC().Truncate(time.Second),
+		ExportedBy:    project.DefaultOwner(),
+		CLIVersion:    version.Version,
+		Root:          filepath.Base(m.Root()),
+		Excludes:      excludes,
+	}
+
+	if r := opts.Redactor; r != nil {
+		bm.Project = r.Text(bm.Project)
+		bm.Root = r.Path(bm.Root)
+		for i := range bm.Customers {
+			bm.Customers[i] = r.Text(bm.Customers[i])
+		}
+	}
+
+	var files []archive.File
+	err = filepath.WalkDir(m.Root(), func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(m.Root(), file)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if Excluded(rel, d.IsDir(), excludes) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		if abs, _ := filepath.Abs(file); abs == destAbs {
+			return nil
+		}
+		// Bundles written earlier with -o inside the project would otherwise
+		// be packed into every later export
+		if IsBundle(file) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry := archive.File{Name: path.Join(bm.Root, rel), Source: file, ModTime: info.ModTime()}
+		sum := File{Path: rel}
+		if r := opts.Redactor; r != nil {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			redacted, keep := r.File(rel, content)
+			if !keep {
+				return nil
+			}
+			sum.Path = r.Path(rel)
+			entry.Name = path.Join(bm.Root, sum.Path)
+			entry.Source, entry.Content = "", redacted
+			sum.Size, sum.SHA256 = int64(len(entry.Content)), checksum(entry.Content)
+		} else if sum.Size, sum.SHA256, err = checksumFile(file); err != nil {
+			return err
+		}
+
+		bm.Files = append(bm.Files, sum)
+		files = append(files, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect project files: %w", err)
+	}
+
+	manifestContent, err := yaml.Marshal(bm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export manifest: %w", err)
+	}
+	files = append([]archive.File{{Name: ManifestFile, Content: manifestContent, ModTime: bm.Exported}}, files...)
+
+	if err := archive.Write(dest, format, files); err != nil {
+		return nil, err
+	}
+	return bm, nil
+}
+
+// Excluded reports whether a project-relative path matches an exclusion rule
+func Excluded(rel string, isDir bool, excludes []string) bool {
+	for _, rule := range excludes {
+		rule = strings.TrimPrefix(strings.TrimSpace(rule), "/")
+		if rule == "" {
+			continue
+		}
+		if dir, ok := strings.CutSuffix(rule, "/"); ok {
+			if rel == dir || strings.HasPrefix(rel, dir+"/") {
+				return true
+			}
+			continue
+		}
+		if isDir {
+			continue
+		}
+		if ok, _ := path.Match(rule, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(rule, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify reads a bundle and checks every file against the manifest's
+// checksums. It reports missing, unexpected and corrupted files.
+func Verify(src string) (*Manifest, error) {
+	bm, err := readManifest(src)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := map[string]File{}
+	for _, f := range bm.Files {
+		expected[f.Path] = f
+	}
+
+	var problems []string
+	seen := map[string]bool{}
+	err = archive.Walk(src, func(name string, r io.Reader) error {
+		if name == ManifestFile {
+			return nil
+		}
+		rel, ok := strings.CutPrefix(name, bm.Root+"/")
+		file, known := expected[rel]
+		if !ok || !known {
+			problems = append(problems, fmt.Sprintf("unexpected file %s", name))
+			return nil
+		}
+		seen[rel] = true
+
+		h := sha256.New()
+		size, err := io.Copy(h, r)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if size != file.Size || hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+			problems = append(problems, fmt.Sprintf("checksum mismatch for %s", rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range bm.Files {
+		if !seen[f.Path] {
+			problems = append(problems, fmt.Sprintf("missing file %s", f.Path))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return bm, fmt.Errorf("bundle %s failed verification:\n  %s", src, strings.Join(problems, "\n  "))
+	}
+	return bm, nil
+}
+
+// Import verifies a bundle and restores its project into parentDir, returning
+// the project root. Layout folders left empty by the export are recreated. It
+// refuses to overwrite an existing folder.
+func Import(src, parentDir string) (*Manifest, string, error) {
+	bm, err := Verify(src)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if bm.Root == "" || bm.Root != filepath.Base(bm.Root) || bm.Root == "." || bm.Root == ".." {
+		return nil, "", fmt.Errorf("bundle %s has an invalid root %q", src, bm.Root)
+	}
+	dest := filepath.Join(parentDir, bm.Root)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, "", fmt.Errorf("%s already exists", dest)
+	}
+
+	tmp, err := os.MkdirTemp(parentDir, ".now-sc-import-")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to import: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := archive.Extract(src, tmp); err != nil {
+		return nil, "", err
+	}
+	m, err := project.LoadManifest(filepath.Join(tmp, bm.Root))
+	if err != nil {
+		return nil, "", fmt.Errorf("bundle does not contain a now-sc project: %w", err)
+	}
+
+	// Bundles only hold files, so recreate the empty layout folders
+	layout, err := m.LoadLayout()
+	if err != nil {
+		return nil, "", err
+	}
+	for _, customer := range append([]project.Customer{{}}, m.Customers...) {
+		if err := project.CreateStructure(m.Root(), layout, customer.Dir); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := os.Rename(m.Root(), dest); err != nil {
+		return nil, "", fmt.Errorf("failed to import: %w", err)
+	}
+	return bm, dest, nil
+}
+
+// IsBundle reports whether file is an archive written by Export, i.e. one
+// whose first entry is the export manifest
+func IsBundle(file string) bool {
+	if _, err := archive.FormatOf(file); err != nil {
+		return false
+	}
+	errStop := errors.New("stop")
+	found := false
+	archive.Walk(file, func(name string, r io.Reader) error {
+		found = name == ManifestFile
+		return errStop
+	})
+	return found
+}
+
+func readManifest(src string) (*Manifest, error) {
+	var content []byte
+	err := archive.Walk(src, func(name string, r io.Reader) error {
+		if name != ManifestFile || content != nil {
+			return nil
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, r); err != nil {
+			return err
+		}
+		content = buf.Bytes()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("%s is not a now-sc export: no %s", src, ManifestFile)
+	}
+
+	var bm Manifest
+	if err := yaml.Unmarshal(content, &bm); err != nil {
+		return nil, fmt.Errorf("invalid export manifest in %s: %w", src, err)
+	}
+	if bm.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("%s was exported by a newer now-sc (bundle format %d); upgrade to import it", src, bm.FormatVersion)
+	}
+	return &bm, nil
+}
+
+func checksumFile(file string) (int64, string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
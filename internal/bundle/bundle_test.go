@@ -0,0 +1,102 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+func TestExportLeavesOutEarlierBundles(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "proj")
+	m := project.NewManifest(root, "proj")
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"notes.md":                           "notes",
+		".env":                               "OPENROUTER_API_KEY=secret",
+		ExportsDir + "/proj-20250101.tar.gz": "earlier bundle",
+		ExportsDir + "/proj.redactions.md":   "original values",
+	}
+	for name, content := range files {
+		path := m.Path(filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dest := m.Path(filepath.FromSlash(ExportsDir), "proj-20250102.tar.gz")
+	bm, err := Export(m, dest, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := map[string]bool{}
+	for _, f := range bm.Files {
+		exported[f.Path] = true
+	}
+	want := []string{"notes.md", project.MetaDir + "/" + project.ManifestFile}
+	for _, path := range want {
+		if !exported[path] {
+			t.Errorf("%s not exported", path)
+		}
+	}
+	if len(bm.Files) != len(want) {
+		t.Errorf("got %v, want only %v", bm.Files, want)
+	}
+
+	if _, err := Verify(dest); err != nil {
+		t.Errorf("bundle does not verify: %v", err)
+	}
+}
+
+func TestExportLeavesOutBundlesWrittenIntoProject(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "proj")
+	m := project.NewManifest(root, "proj")
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(m.Path("notes.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(m.Path("out"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// A plain archive that is not a now-sc export is project material
+	if err := os.WriteFile(m.Path("out", "data.zip"), []byte("not a bundle"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Export(m, m.Path("out", "first.tar.gz"), Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Export(m, m.Path("out", "second.zip"), Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if !IsBundle(m.Path("out", "first.tar.gz")) || !IsBundle(m.Path("out", "second.zip")) {
+		t.Fatal("exports not recognised as bundles")
+	}
+
+	bm, err := Export(m, m.Path("out", "third.tar.gz"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := map[string]bool{}
+	for _, f := range bm.Files {
+		exported[f.Path] = true
+	}
+	for _, path := range []string{"out/first.tar.gz", "out/second.zip"} {
+		if exported[path] {
+			t.Errorf("earlier bundle %s was exported", path)
+		}
+	}
+	for _, path := range []string{"notes.md", "out/data.zip"} {
+		if !exported[path] {
+			t.Errorf("%s not exported", path)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/actions"
+	"github.com/Now-AI-Foundry/Now-SC/internal/history"
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	actionsProvider string
+	actionsModel    string
+	actionsAll      bool
+	actionsOwner    string
+)
+
+var actionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "Extract and track action items",
+	Long: `Tracks the follow-ups from calls, emails and notes in actions.yaml at the
+project root. "extract" has the model pull each action's description, owner, due
+date and where it was said out of a file; actions already tracked are merged
+rather than added twice. Every change also rewrites the Markdown rollup
+Action_Items.md in the layout's overview folder (99_Assets/Project_Overview by
+default).`,
+}
+
+var actionsExtractCmd = &cobra.Command{
+	Use:          "extract <file...>",
+	Short:        "Extract action items from transcripts, emails, notes or documents",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runActionsExtract,
+}
+
+var actionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List open action items",
+	Args:  cobra.NoArgs,
+	RunE:  runActionsList,
+}
+
+var actionsDoneCmd = &cobra.Command{
+	Use:          "done <id...>",
+	Short:        "Mark action items as done",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runActionsDone,
+}
+
+var actionsOverdueCmd = &cobra.Command{
+	Use:   "overdue",
+	Short: "List open action items past their due date",
+	Args:  cobra.NoArgs,
+	RunE:  runActionsOverdue,
+}
+
+func init() {
+	actionsExtractCmd.Flags().StringVar(&actionsProvider, "provider", provider.OpenRouter, "Provider extracting the actions (openrouter, fake)")
+	actionsExtractCmd.Flags().StringVar(&actionsModel, "model", "", "Model extracting the actions; it must support structured output")
+
+	actionsListCmd.Flags().BoolVarP(&actionsAll, "all", "a", false, "Include completed actions")
+	actionsListCmd.Flags().StringVar(&actionsOwner, "owner", "", "Only show actions owned by this person")
+
+	actionsCmd.AddCommand(actionsExtractCmd)
+	actionsCmd.AddCommand(actionsListCmd)
+	actionsCmd.AddCommand(actionsDoneCmd)
+	actionsCmd.AddCommand(actionsOverdueCmd)
+}
+
+func runActionsExtract(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+	p, err := provider.New(actionsProvider, actionsModel)
+	if err != nil {
+		return err
+	}
+	list, err := actions.Load(manifest)
+	if err != nil {
+		return err
+	}
+
+	var extracted []string
+	extractErr := func() error {
+		for _, arg := range args {
+			file, err := projectRelative(manifest, arg)
+			if err != nil {
+				return err
+			}
+			content, err := ingest.Text(manifest.Path(file))
+			if err != nil {
+				return fmt.Errorf("cannot read %s: %w", arg, err)
+			}
+
+			fmt.Println(color.CyanString("Extracting actions from %s...", filepath.ToSlash(file)))
+			items, err := actions.Extract(p, filepath.ToSlash(file), content, actions.DocumentDate(manifest.Path(file)))
+			if err != nil {
+				return err
+			}
+			added, duplicates := list.Merge(items)
+			color.Green("✓ %s: %d new, %d already tracked", filepath.ToSlash(file), added, duplicates)
+			extracted = append(extracted, file)
+		}
+		return nil
+	}()
+	if len(extracted) == 0 {
+		return extractErr
+	}
+
+	// Keep what earlier files yielded even when a later one failed
+	if err := saveActions(manifest, layout, list); err != nil {
+		return err
+	}
+	for _, file := range extracted {
+		if err := history.RecordRun(manifest, history.CommandActions, "<actions>", p.Name(), p.Model(), actions.File, []string{file}); err != nil {
+			color.Yellow("Warning: could not record the run in the history: %v", err)
+		}
+	}
+	return extractErr
+}
+
+func runActionsList(cmd *cobra.Command, args []string) error {
+	manifest, err := loadProject()
+	if err != nil {
+		return err
+	}
+	list, err := actions.Load(manifest)
+	if err != nil {
+		return err
+	}
+
+	items := list.Open()
+	if actionsAll {
+		items = append(items, list.Completed()...)
+	}
+	if actionsOwner != "" {
+		var owned []actions.Item
+		for _, item := range items {
+			if strings.Contains(strings.ToLower(item.Owner), strings.ToLower(actionsOwner)) {
+				owned = append(owned, item)
+			}
+		}
+		items = owned
+	}
+	if len(items) == 0 {
+		color.Green("✓ No open actions")
+		return nil
+	}
+	return printActions(items)
+}
+
+func runActionsDone(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+	list, err := actions.Load(manifest)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range args {
+		item, err := list.Done(id, time.Now())
+		if err != nil {
+			return err
+		}
+		color.Green("✓ %s done: %s", item.ID, item.Description)
+	}
+	return saveActions(manifest, layout, list)
+}
+
+func runActionsOverdue(cmd *cobra.Command, args []string) error {
+	manifest, err := loadProject()
+	if err != nil {
+		return err
+	}
+	list, err := actions.Load(manifest)
+	if err != nil {
+		return err
+	}
+
+	overdue := list.Overdue(time.Now())
+	if len(overdue) == 0 {
+		color.Green("✓ Nothing overdue")
+		return nil
+	}
+	return printActions(overdue)
+}
+
+func printActions(items []actions.Item) error {
+	today := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tDUE\tOWNER\tACTION\tSOURCE")
+	for _, item := range items {
+		status := item.Status
+		if item.IsOverdue(today) {
+			status = "overdue"
+		}
+		var sources []string
+		for _, s := range item.Sources {
+			sources = append(sources, filepath.Base(s.File))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", item.ID, status, orDash(item.Due), orDash(item.Owner),
+			item.Description, orDash(strings.Join(sources, ", ")))
+	}
+	return w.Flush()
+}
+
+// saveActions writes actions.yaml and its Markdown rollup
+func saveActions(manifest *project.Manifest, layout *project.Layout, list *actions.List) error {
+	if err := list.Save(manifest); err != nil {
+		return err
+	}
+	if err := list.WriteRollup(manifest, layout, time.Now()); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s and %s\n", actions.File, actions.RollupFile(layout))
+	return nil
+}
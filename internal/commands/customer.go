@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	customerIndustry    string
+	customerSize        string
+	customerInstanceURL string
+	customerKeepFiles   bool
+	customerYes         bool
+)
+
+var customerCmd = &cobra.Command{
+	Use:   "customer",
+	Short: "Manage the customers of a project",
+	Long: `Adds, lists, renames and removes customer folders. Each customer gets a folder
+under the customers directory with a profile.yaml describing its industry, size,
+ServiceNow instance and key contacts.`,
+}
+
+var customerAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a customer, partner or business unit",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCustomerAdd,
+}
+
+var customerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the project's customers",
+	Args:  cobra.NoArgs,
+	RunE:  runCustomerList,
+}
+
+var customerRenameCmd = &cobra.Command{
+	Use:   "rename <customer> <new-name>",
+	Short: "Rename a customer and its folder",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runCustomerRename,
+}
+
+var customerRemoveCmd = &cobra.Command{
+	Use:   "remove <customer>",
+	Short: "Remove a customer and its folder",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCustomerRemove,
+}
+
+func init() {
+	customerAddCmd.Flags().StringVar(&customerIndustry, "industry", "", "Customer industry")
+	customerAddCmd.Flags().StringVar(&customerSize, "size", "", "Customer size, e.g. employee count")
+	customerAddCmd.Flags().StringVar(&customerInstanceURL, "instance-url", "", "Customer ServiceNow instance URL")
+
+	customerRemoveCmd.Flags().BoolVar(&customerKeepFiles, "keep-files", false, "Unregister the customer but keep its folder")
+	customerRemoveCmd.Flags().BoolVarP(&customerYes, "yes", "y", false, "Do not ask for confirmation")
+
+	customerCmd.AddCommand(customerAddCmd)
+	customerCmd.AddCommand(customerListCmd)
+	customerCmd.AddCommand(customerRenameCmd)
+	customerCmd.AddCommand(customerRemoveCmd)
+}
+
+func runCustomerAdd(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	customer, err := manifest.AddCustomer(layout, project.CustomerProfile{
+		Name:        args[0],
+		Industry:    customerIndustry,
+		Size:        customerSize,
+		InstanceURL: customerInstanceURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+
+	color.Green("✓ Customer \"%s\" added", customer.Name)
+	fmt.Printf("Edit %s to record contacts and details.\n", relPath(manifest.CustomerPath(layout, customer), project.CustomerProfileFile))
+	return nil
+}
+
+func runCustomerList(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	if len(manifest.Customers) == 0 {
+		color.Yellow("No customers. Add one with \"now-sc customer add <name>\".")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CUSTOMER\tFOLDER\tINDUSTRY\tSIZE\tINSTANCE\tCONTACTS")
+	for _, customer := range manifest.Customers {
+		profile, err := project.LoadCustomerProfile(manifest.CustomerPath(layout, customer))
+		if err != nil {
+			profile = &project.CustomerProfile{}
+		}
+
+		contacts := 0
+		for _, contact := range profile.Contacts {
+			if contact.Name != "" {
+				contacts++
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n",
+			customer.Name, customer.Dir, profile.Industry, profile.Size, profile.InstanceURL, contacts)
+	}
+	return w.Flush()
+}
+
+func runCustomerRename(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	customer, err := manifest.RenameCustomer(layout, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+
+	color.Green("✓ Customer renamed to \"%s\" (%s)", customer.Name, customer.Dir)
+	return nil
+}
+
+func runCustomerRemove(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	i := manifest.FindCustomer(args[0])
+	if i < 0 {
+		return fmt.Errorf("customer %q not found", args[0])
+	}
+
+	if !customerYes {
+		label := fmt.Sprintf("Remove %s and delete %s", manifest.Customers[i].Name, relPath(manifest.CustomerPath(layout, manifest.Customers[i])))
+		if customerKeepFiles {
+			label = fmt.Sprintf("Remove %s from the project (files are kept)", manifest.Customers[i].Name)
+		}
+		confirm := promptui.Prompt{Label: label, IsConfirm: true}
+		if _, err := confirm.Run(); err != nil {
+			color.Yellow("Removal cancelled.")
+			return nil
+		}
+	}
+
+	customer, err := manifest.RemoveCustomer(layout, args[0], customerKeepFiles)
+	if err != nil {
+		return err
+	}
+
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+
+	color.Green("✓ Customer \"%s\" removed", customer.Name)
+	return nil
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/doctor"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check a project for drift and problems",
+	Long: `Checks the project against its layout and manifest:
+
+  structure    every layout folder and customer folder exists, nothing unexpected
+  manifest     layout version, stage, customers and layout snapshot
+  prompts      base prompts exist and match the versions recorded at install
+  environment  OPENROUTER_API_KEY (and optionally GITHUB_PAT) are set, in the
+               environment or the project's .env
+  gitignore    .env is excluded from git
+  secrets      no API keys, tokens or private keys in tracked files
+
+With --fix, recreates missing folders, restores missing base prompts from
+upstream, adds .env to .gitignore and rewrites the layout snapshot. Locally
+modified prompts and leaked secrets are reported but never changed.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair what can be repaired automatically")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	report := doctor.Run(manifest, layout)
+	printDoctorReport(report)
+
+	if doctorFix {
+		fixable := report.Fixable()
+		if len(fixable) == 0 {
+			fmt.Println()
+			fmt.Println("Nothing to fix automatically.")
+		} else {
+			fmt.Println()
+			color.Cyan("Fixing...")
+			for _, finding := range fixable {
+				if err := finding.Fix(); err != nil {
+					color.Red("  ✗ could not fix \"%s\": %v", finding.Message, err)
+				} else {
+					color.Green("  ✓ fixed: %s", finding.Message)
+				}
+			}
+			if err := manifest.Save(); err != nil {
+				return err
+			}
+			report = doctor.Run(manifest, layout)
+		}
+	}
+
+	problems, warnings := report.Count(doctor.Problem), report.Count(doctor.Warning)
+	summary := fmt.Sprintf("%d problem(s), %d warning(s)", problems, warnings)
+	fmt.Println()
+	switch {
+	case problems > 0:
+		color.Red(summary)
+		if n := len(report.Fixable()); n > 0 && !doctorFix {
+			color.Yellow("Run \"now-sc doctor --fix\" to repair %d of them.", n)
+		}
+		return fmt.Errorf("doctor found %d problem(s)", problems)
+	case warnings > 0:
+		color.Yellow(summary)
+	default:
+		color.Green("✓ Project is healthy")
+	}
+	return nil
+}
+
+func printDoctorReport(report *doctor.Report) {
+	check := ""
+	for _, finding := range report.Findings {
+		if finding.Check != check {
+			check = finding.Check
+			fmt.Println()
+			color.Cyan("%s", check)
+		}
+
+		fixable := ""
+		if finding.Fix != nil {
+			fixable = " (fixable)"
+		}
+		switch finding.Severity {
+		case doctor.OK:
+			color.Green("  ✓ %s", finding.Message)
+		case doctor.Warning:
+			color.Yellow("  ! %s%s", finding.Message, fixable)
+		default:
+			color.Red("  ✗ %s%s", finding.Message, fixable)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/email"
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emailOutput string
+	emailStdout bool
+)
+
+var emailCmd = &cobra.Command{
+	Use:   "email <file...>",
+	Short: "Convert .eml and .mbox files to Markdown threads",
+	Long: `Parses .eml and .mbox files (MIME multipart, quoted-printable and base64 parts,
+HTML bodies), groups the messages into threads by Message-ID, In-Reply-To and
+References, and writes each thread as <date>_<subject>.md with its headers.
+Quoted replies are dropped since earlier messages appear in full. Attachments
+are saved to a <date>_<subject>_attachments folder next to the thread.
+
+Threads are written to the inbox emails folder. "now-sc ingest" does this
+automatically for the mail files it files.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runEmail,
+}
+
+func init() {
+	emailCmd.Flags().StringVarP(&emailOutput, "output", "o", "", "Folder to write the threads to")
+	emailCmd.Flags().BoolVar(&emailStdout, "stdout", false, "Print the threads instead of writing them")
+}
+
+func runEmail(cmd *cobra.Command, args []string) error {
+	var messages []*email.Message
+	for _, file := range args {
+		parsed, err := email.ParseFile(file)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, parsed...)
+	}
+	threads := email.Threads(messages)
+
+	if emailStdout {
+		for i, thread := range threads {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(string(thread.Markdown()))
+		}
+		return nil
+	}
+
+	dir := emailOutput
+	if dir == "" {
+		manifest, layout, err := loadProjectLayout()
+		if err != nil {
+			return err
+		}
+		dir = manifest.Path(ingest.Folder(layout, ingest.KindEmail, ""))
+	}
+
+	for _, thread := range threads {
+		path, err := thread.Write(dir)
+		if err != nil {
+			return err
+		}
+		attachments := 0
+		for _, msg := range thread.Messages {
+			attachments += len(msg.Attachments)
+		}
+		color.Green("✓ %s (%d message(s), %d attachment(s)) → %s", thread.Subject, len(thread.Messages), attachments, relPath(path))
+	}
+	fmt.Printf("\n%d message(s) in %d thread(s) from %d file(s)\n", len(messages), len(threads), len(args))
+	if len(threads) > 0 && emailOutput == "" {
+		fmt.Printf("Attach a thread to a prompt with: now-sc prompt --attach %s\n", filepath.Join(relPath(dir), threads[0].Name()+".md"))
+	}
+	return nil
+}
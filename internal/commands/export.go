@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/archive"
+	"github.com/Now-AI-Foundry/Now-SC/internal/bundle"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/sanitize"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput       string
+	exportFormat       string
+	exportExcludes     []string
+	exportExcludeInbox bool
+	exportSanitize     bool
+	exportReport       string
+	importInto         string
+	importVerifyOnly   bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the project as a zip or tar.gz bundle",
+	Long: `Packs the project into a bundle for archiving or hand-over to the delivery team.
+The bundle holds an export manifest (now-sc-export.yaml) with the project details,
+the exclusion rules used and a SHA-256 checksum of every file.
+
+The bundle is written to .now-sc/exports/ unless --output is given; that folder,
+.env, .git/ and earlier now-sc bundles anywhere in the project are never
+exported. Add rules with --exclude: "dir/" excludes a
+folder, other patterns match a project-relative path or a file name
+(e.g. --exclude "*.mp4"). --exclude-inbox leaves out the raw calls, transcripts
+and emails in the inbox.
+
+--sanitize builds a reference project for reuse with other customers: customer
+and contact names from the manifest and customer profiles, email addresses,
+phone numbers and ServiceNow instance URLs are replaced with placeholders such
+as "Customer A" and "Contact 1", in file contents and paths. Binary files are
+left out. A redaction report listing every replacement is written next to the
+bundle for review; it contains the original values, so do not share it.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Restore a project from an export bundle",
+	Long: `Verifies every file of a bundle made by "now-sc export" against its checksums,
+restores the project into a new folder and registers it in the workspace.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runImport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Bundle path (default: .now-sc/exports/<project>-<date>.<format>)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", archive.FormatTarGz, "Bundle format when --output is not set (tar.gz, zip)")
+	exportCmd.Flags().StringArrayVar(&exportExcludes, "exclude", nil, "Exclude files or folders (repeatable)")
+	exportCmd.Flags().BoolVar(&exportExcludeInbox, "exclude-inbox", false, "Leave out the inbox with raw calls, transcripts and emails")
+	exportCmd.Flags().BoolVar(&exportSanitize, "sanitize", false, "Replace customer names, contacts, emails, phone numbers and instance URLs with placeholders")
+	exportCmd.Flags().StringVar(&exportReport, "report", "", "Redaction report path for --sanitize (default: <bundle>.redactions.md)")
+
+	importCmd.Flags().StringVar(&importInto, "into", ".", "Folder to restore the project into")
+	importCmd.Flags().BoolVar(&importVerifyOnly, "verify-only", false, "Only verify the bundle's checksums")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	var sanitizer *sanitize.Sanitizer
+	opts := bundle.Options{Excludes: exportExcludes}
+	if exportSanitize {
+		sanitizer = sanitize.New(manifest, layout)
+		opts.Redactor = sanitizer
+	}
+
+	dest := exportOutput
+	if dest == "" {
+		if exportFormat != archive.FormatTarGz && exportFormat != archive.FormatZip {
+			return fmt.Errorf("unknown format %q (expected tar.gz or zip)", exportFormat)
+		}
+		name := filepath.Base(manifest.Root())
+		if sanitizer != nil {
+			name = sanitizer.Path(name) + "-reference"
+		}
+		// Kept inside the project, where later exports leave them out
+		dir := manifest.Path(filepath.FromSlash(bundle.ExportsDir))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", bundle.ExportsDir, err)
+		}
+		dest = filepath.Join(dir, fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), exportFormat))
+	}
+	if exportExcludeInbox {
+		inbox := layout.InboxDir()
+		if inbox == "" {
+			return fmt.Errorf("the project layout has no inbox folder")
+		}
+		opts.Excludes = append(opts.Excludes, filepath.ToSlash(inbox)+"/")
+	}
+
+	fmt.Println(color.CyanString("Exporting %s...", manifest.Project))
+	bm, err := bundle.Export(manifest, dest, opts)
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	for _, f := range bm.Files {
+		size += f.Size
+	}
+	color.Green("✓ Exported %d files (%s) to %s", len(bm.Files), formatSize(size), relPath(dest))
+	fmt.Printf("  Excluded: %s\n", strings.Join(bm.Excludes, ", "))
+	fmt.Printf("  Checksums recorded in %s\n", bundle.ManifestFile)
+
+	if sanitizer != nil {
+		return writeRedactionReport(sanitizer, dest)
+	}
+	return nil
+}
+
+func writeRedactionReport(sanitizer *sanitize.Sanitizer, dest string) error {
+	report := exportReport
+	if report == "" {
+		report = strings.TrimSuffix(strings.TrimSuffix(dest, ".zip"), ".tar.gz") + ".redactions.md"
+	}
+	if err := os.WriteFile(report, sanitizer.Report(dest), 0600); err != nil {
+		return fmt.Errorf("failed to write redaction report: %w", err)
+	}
+
+	counts := sanitizer.Counts()
+	fmt.Println()
+	color.Cyan("Sanitized: %d customer, %d contact, %d email, %d phone and %d instance replacement(s)",
+		counts[sanitize.KindCustomer], counts[sanitize.KindContact], counts[sanitize.KindEmail],
+		counts[sanitize.KindPhone], counts[sanitize.KindInstance])
+	if len(sanitizer.Skipped) > 0 {
+		color.Yellow("  %d binary file(s) left out because they cannot be sanitized", len(sanitizer.Skipped))
+	}
+	color.Yellow("Review %s before sharing the bundle. It lists the original values; do not share it.", relPath(report))
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	if importVerifyOnly {
+		bm, err := bundle.Verify(args[0])
+		if err != nil {
+			return err
+		}
+		color.Green("✓ %s: %d files verified (project %s, exported %s)",
+			args[0], len(bm.Files), bm.Project, bm.Exported.Local().Format("2006-01-02 15:04"))
+		return nil
+	}
+
+	fmt.Println(color.CyanString("Verifying %s...", args[0]))
+	bm, root, err := bundle.Import(args[0], importInto)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := project.LoadManifest(root)
+	if err != nil {
+		return err
+	}
+	registerProject(manifest)
+
+	color.Green("✓ Imported %s (%d files) into %s", bm.Project, len(bm.Files), relPath(root))
+	if bm.ExportedBy != "" {
+		fmt.Printf("  Exported by %s on %s\n", bm.ExportedBy, bm.Exported.Local().Format("2006-01-02 15:04"))
+	}
+	if len(bm.Excludes) > 0 {
+		fmt.Printf("  Not included in the bundle: %s\n", strings.Join(bm.Excludes, ", "))
+	}
+	return nil
+}
+
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
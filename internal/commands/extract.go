@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/extract"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	extractOutput string
+	extractStdout bool
+)
+
+var extractCmd = &cobra.Command{
+	Use:   "extract <file...>",
+	Short: "Extract the text of PDF, Word, PowerPoint and Excel files as Markdown",
+	Long: `Converts documents to Markdown for use as prompt context: Word headings, lists
+and tables; one section per PowerPoint slide with its title, bullets and
+speaker notes; one table per Excel sheet; and the text of PDFs.
+
+The Markdown is cached next to the document as <name>.<ext>.md and reused until
+the document changes. "now-sc prompt --attach" and "now-sc ingest" extract
+documents the same way.
+
+PDF support covers text-based PDFs only: scanned pages and fonts with custom
+encodings yield no text.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runExtract,
+}
+
+func init() {
+	extractCmd.Flags().StringVarP(&extractOutput, "output", "o", "", "Folder to write the Markdown to instead of next to each file")
+	extractCmd.Flags().BoolVar(&extractStdout, "stdout", false, "Print the Markdown instead of writing it")
+}
+
+func runExtract(cmd *cobra.Command, args []string) error {
+	for _, file := range args {
+		if !extract.Supported(file) {
+			return fmt.Errorf("cannot extract %s: supported formats are .pdf, .docx, .pptx and .xlsx", file)
+		}
+
+		if extractStdout {
+			text, err := extract.File(file)
+			if err != nil {
+				return err
+			}
+			fmt.Print(text)
+			continue
+		}
+
+		dest := extract.CachePath(file)
+		if extractOutput != "" {
+			text, err := extract.File(file)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(extractOutput, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", extractOutput, err)
+			}
+			dest = filepath.Join(extractOutput, filepath.Base(dest))
+			if err := os.WriteFile(dest, []byte(text), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", dest, err)
+			}
+		} else if _, err := extract.Cached(file); err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(dest)
+		if err != nil {
+			return err
+		}
+		color.Green("✓ %s (%d lines) → %s", file, strings.Count(string(content), "\n"), relPath(dest))
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/history"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyTemplate string
+	historyCustomer string
+	historySince    string
+	historyUntil    string
+	historyLimit    int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Browse the outputs generated in this project",
+	Long: `Lists the prompt runs that saved an output, newest first: "now-sc prompt",
+watch workflows, regenerated outputs, overview updates and action extraction.
+Each run is indexed in .now-sc/history.jsonl with its template, model, output,
+inputs, customer and token usage.
+
+Dates are YYYY-MM-DD and inclusive. "show" prints the details of a run and
+"open" opens its output in $EDITOR; both take the run's ID or output path.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runHistory,
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:          "show <id|output>",
+	Short:        "Show the details of a run",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runHistoryShow,
+}
+
+var historyOpenCmd = &cobra.Command{
+	Use:          "open <id|output>",
+	Short:        "Open the output of a run in $EDITOR",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runHistoryOpen,
+}
+
+func init() {
+	historyCmd.Flags().StringVarP(&historyTemplate, "template", "t", "", "Only runs of templates whose path contains this")
+	historyCmd.Flags().StringVarP(&historyCustomer, "customer", "c", "", "Only runs for this customer")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only runs on or after this date")
+	historyCmd.Flags().StringVar(&historyUntil, "until", "", "Only runs on or before this date")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "Show at most this many runs")
+
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyOpenCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	manifest, err := loadProject()
+	if err != nil {
+		return err
+	}
+	entries, err := loadHistory(manifest)
+	if err != nil {
+		return err
+	}
+
+	filter := history.Filter{Template: historyTemplate, Customer: historyCustomer}
+	if i := manifest.FindCustomer(historyCustomer); historyCustomer != "" && i >= 0 {
+		filter.Customer = manifest.Customers[i].Name
+	}
+	if filter.Since, err = parseHistoryDate(historySince, false); err != nil {
+		return err
+	}
+	if filter.Until, err = parseHistoryDate(historyUntil, true); err != nil {
+		return err
+	}
+
+	selected := history.Select(entries, filter)
+	if len(selected) == 0 {
+		color.Yellow("No runs found")
+		return nil
+	}
+	if historyLimit > 0 && len(selected) > historyLimit {
+		selected = selected[:historyLimit]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tDATE\tCUSTOMER\tTEMPLATE\tMODEL\tTOKENS\tOUTPUT")
+	for _, e := range selected {
+		tokens := "-"
+		if e.Tokens() > 0 {
+			tokens = strconv.Itoa(e.Tokens())
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", e.ID, e.Time.Local().Format("2006-01-02 15:04"),
+			orDash(e.Customer), templateName(e.Template), e.Model, tokens, e.Output)
+	}
+	return w.Flush()
+}
+
+func runHistoryShow(cmd *cobra.Command, args []string) error {
+	manifest, e, err := findHistoryEntry(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Run %d, %s by %s\n", e.ID, e.Time.Local().Format("2006-01-02 15:04:05"), e.Command)
+	fmt.Printf("  Output:    %s", e.Output)
+	if _, err := os.Stat(provenance.Resolve(manifest.Root(), e.Output)); err != nil {
+		fmt.Print(color.RedString(" (missing)"))
+	}
+	fmt.Println()
+	if e.RegeneratedFrom != "" {
+		fmt.Printf("  Previous:  %s\n", e.RegeneratedFrom)
+	}
+	fmt.Printf("  Template:  %s\n", e.Template)
+	if e.Workflow != "" {
+		fmt.Printf("  Workflow:  %s\n", e.Workflow)
+	}
+	fmt.Printf("  Model:     %s (%s)\n", e.Model, e.Provider)
+	fmt.Printf("  Customer:  %s\n", orDash(e.Customer))
+	if e.Input != "" {
+		fmt.Printf("  Input:     %s\n", e.Input)
+	}
+	if len(e.Inputs) > 0 {
+		fmt.Println("  Files:")
+		for _, input := range e.Inputs {
+			fmt.Printf("    %s\n", input)
+		}
+	}
+	if e.Usage != nil {
+		fmt.Printf("  Tokens:    %d (%d prompt, %d completion)\n", e.Usage.TotalTokens, e.Usage.PromptTokens, e.Usage.CompletionTokens)
+	}
+	return nil
+}
+
+func runHistoryOpen(cmd *cobra.Command, args []string) error {
+	manifest, e, err := findHistoryEntry(args[0])
+	if err != nil {
+		return err
+	}
+	// Outputs outside the project are recorded as absolute paths
+	path := provenance.Resolve(manifest.Root(), e.Output)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("output of run %d is gone: %w", e.ID, err)
+	}
+
+	// $EDITOR may carry arguments, e.g. "code --wait"
+	fields := strings.Fields(os.Getenv("VISUAL"))
+	if len(fields) == 0 {
+		fields = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(fields) == 0 {
+		fields = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			fields = []string{"notepad"}
+		}
+	}
+	ed := exec.Command(fields[0], append(fields[1:], path)...)
+	ed.Stdin, ed.Stdout, ed.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return ed.Run()
+}
+
+// loadHistory reads the project's history, warning about lines it skipped
+func loadHistory(manifest *project.Manifest) ([]history.Entry, error) {
+	entries, skipped, err := history.Load(manifest)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range skipped {
+		color.Yellow("Warning: skipped invalid history entry on %s", problem)
+	}
+	return entries, nil
+}
+
+// findHistoryEntry finds a run by ID or by its output path, relative to the
+// current directory
+func findHistoryEntry(ref string) (*project.Manifest, *history.Entry, error) {
+	manifest, err := loadProject()
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := loadHistory(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := strconv.Atoi(ref); err != nil {
+		if ref, err = projectRelative(manifest, ref); err != nil {
+			return nil, nil, err
+		}
+	}
+	e, err := history.Find(entries, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, e, nil
+}
+
+// parseHistoryDate parses a YYYY-MM-DD date as the start of the day, or its
+// end for an inclusive upper bound
+func parseHistoryDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return day, nil
+}
+
+// templateName shortens a template path to its file name
+func templateName(path string) string {
+	if strings.HasPrefix(path, "<") {
+		return path
+	}
+	return filepath.Base(filepath.FromSlash(path))
+}
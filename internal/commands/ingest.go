@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ingestLLM      bool
+	ingestProvider string
+	ingestModel    string
+	ingestDryRun   bool
+)
+
+var ingestCmd = &cobra.Command{
+	Use:   "ingest [file...]",
+	Short: "Classify new inbox files and file them into place",
+	Long: `Sorts the files dropped at the top of the inbox (or the given files). Each file
+is recognised as a transcript, email, note or deck from its extension, name and
+content, tagged with the customer it mentions, renamed to
+<date>_<customer>_<title>.<ext> and moved into the matching inbox folder: calls
+(split into internal and external), emails, notes or decks.
+
+With --llm, text files are classified by the model, which also decides whether a
+call was internal or external and suggests a title. Every decision is logged to
+.now-sc/ingest.log.`,
+	SilenceUsage: true,
+	RunE:         runIngest,
+}
+
+func init() {
+	ingestCmd.Flags().BoolVar(&ingestLLM, "llm", false, "Classify text files with the model")
+	ingestCmd.Flags().StringVar(&ingestProvider, "provider", provider.OpenRouter, "Provider used with --llm (openrouter, fake)")
+	ingestCmd.Flags().StringVar(&ingestModel, "model", "", "Model used with --llm")
+	ingestCmd.Flags().BoolVar(&ingestDryRun, "dry-run", false, "Show where files would go without moving them")
+}
+
+func runIngest(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+
+	in := &ingest.Ingester{Manifest: manifest, Layout: layout}
+	if ingestLLM {
+		if in.Provider, err = provider.New(ingestProvider, ingestModel); err != nil {
+			return err
+		}
+	}
+
+	var files []string
+	if len(args) == 0 {
+		if files, err = in.Pending(); err != nil {
+			return err
+		}
+	}
+	for _, arg := range args {
+		file, err := projectRelative(manifest, arg)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		color.Green("✓ Nothing to ingest: the top of %s is empty", filepath.ToSlash(layout.InboxDir()))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tKIND\tAUDIENCE\tCUSTOMER\tDESTINATION")
+	var failed int
+	var warnings []string
+	for _, file := range files {
+		d, err := in.Plan(file)
+		if err == nil && !ingestDryRun {
+			err = in.Apply(d)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\t-\t-\t-\t%s\n", filepath.ToSlash(file), color.RedString("error: %v", err))
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", filepath.Base(d.Source), d.Kind, orDash(d.Audience), orDash(d.Customer), d.Dest)
+		if d.Error != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: model classification failed, used rules: %s", filepath.Base(d.Source), d.Error))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		color.Yellow("  %s", warning)
+	}
+
+	fmt.Println()
+	switch {
+	case failed > 0:
+		return fmt.Errorf("%d of %d file(s) could not be ingested", failed, len(files))
+	case ingestDryRun:
+		color.Yellow("Dry run: no files were moved")
+	default:
+		color.Green("✓ Ingested %d file(s); decisions logged to %s", len(files),
+			filepath.ToSlash(filepath.Join(project.MetaDir, ingest.LogFile)))
+	}
+	return nil
+}
+
+// projectRelative returns a path relative to the project root, refusing
+// paths outside the project
+func projectRelative(manifest *project.Manifest, path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.Abs(manifest.Root())
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not inside the project", path)
+	}
+	return rel, nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
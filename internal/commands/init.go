@@ -1,12 +1,17 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/Now-AI-Foundry/Now-SC/internal/archive"
 	"github.com/Now-AI-Foundry/Now-SC/internal/github"
 	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
@@ -15,21 +20,39 @@ import (
 var (
 	projectName  string
 	customerName string
+	profileName  string
+	ownerName    string
+	stageName    string
 	noGitHub     bool
+	initForce    bool
+	initYes      bool
+	initDryRun   bool
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new presales project",
 	Long: `Creates a new presales project with the standard directory structure,
-fetches base prompts from GitHub, and optionally creates a GitHub repository.`,
+fetches base prompts from GitHub, and optionally creates a GitHub repository.
+
+If the directory already exists, init merges into it: only missing folders and
+files are created and existing files are never overwritten. Use --force to
+back the directory up to a timestamped archive and recreate it from scratch
+(after confirmation, or with --yes), and --dry-run to print the plan without
+changing anything.`,
 	RunE: runInit,
 }
 
 func init() {
 	initCmd.Flags().StringVarP(&projectName, "name", "n", "", "Project name")
 	initCmd.Flags().StringVarP(&customerName, "customer", "c", "", "Customer name")
+	initCmd.Flags().StringVarP(&profileName, "profile", "p", project.DefaultProfile, "Project profile (see \"now-sc profiles list\")")
+	initCmd.Flags().StringVar(&ownerName, "owner", "", "Project owner (default: git user.name)")
+	initCmd.Flags().StringVar(&stageName, "stage", "discovery", "Sales stage ("+strings.Join(prompts.Stages, ", ")+")")
 	initCmd.Flags().BoolVar(&noGitHub, "no-github", false, "Skip GitHub repository creation")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Recreate an existing directory after backing it up to a timestamped archive")
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Do not ask for confirmation before recreating a directory with --force")
+	initCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "Print what init would do without changing anything")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -53,7 +76,8 @@ func runInit(cmd *cobra.Command, args []string) error {
 				if input == "" {
 					return fmt.Errorf("customer name is required")
 				}
-				return nil
+				_, err := project.Slug(input)
+				return err
 			},
 		}
 		result, err := prompt.Run()
@@ -63,53 +87,167 @@ func runInit(cmd *cobra.Command, args []string) error {
 		customerName = result
 	}
 
+	customerDir, err := project.Slug(customerName)
+	if err != nil {
+		return err
+	}
+	customer := project.Customer{Name: customerName, Dir: customerDir}
+
+	if !isKnownStage(stageName) {
+		return fmt.Errorf("unknown stage %q (expected one of %s)", stageName, strings.Join(prompts.Stages, ", "))
+	}
+
 	projectPath := filepath.Join(".", projectName)
 
-	// Check if directory already exists
-	if _, err := os.Stat(projectPath); err == nil {
-		prompt := promptui.Prompt{
-			Label:     fmt.Sprintf("Directory %s already exists. Overwrite", projectName),
-			IsConfirm: true,
+	profile, err := project.LoadProfile(profileName)
+	if err != nil {
+		return err
+	}
+
+	layout, err := profile.ResolveLayout()
+	if err != nil {
+		return fmt.Errorf("failed to load project layout: %w", err)
+	}
+
+	// Work out what init will do before touching the disk
+	owner := ownerName
+	if owner == "" {
+		owner = project.DefaultOwner()
+	}
+	seedData := project.NewSeedData(layout, projectName, customer, owner, strings.ToLower(stageName), profile.Name)
+	starterFiles, err := project.ProjectFiles(seedData)
+	if err != nil {
+		return err
+	}
+	customerProfile, err := project.CustomerProfileSeed(layout, customer)
+	if err != nil {
+		return err
+	}
+	starterFiles = append(starterFiles, customerProfile)
+	profileFiles, err := profile.SeedFiles(seedData)
+	if err != nil {
+		return err
+	}
+	starterFiles = append(starterFiles, profileFiles...)
+
+	exists := false
+	if info, err := os.Stat(projectPath); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("%s exists and is not a directory", projectPath)
 		}
-		_, err := prompt.Run()
+		exists = true
+	}
+
+	// Merging needs the existing manifest; a broken one is not replaced
+	if exists && !initForce {
+		if _, err := project.LoadManifest(projectPath); err != nil && !errors.Is(err, project.ErrNotProject) {
+			return fmt.Errorf("cannot merge into %s: %w", projectPath, err)
+		}
+	}
+
+	plan := planInit(projectPath, layout, customer, starterFiles, exists)
+	if initDryRun {
+		printInitPlan(plan)
+		return nil
+	}
+
+	if exists && initForce {
+		if !initYes {
+			confirm := promptui.Prompt{
+				Label:     fmt.Sprintf("Back up %s and recreate it from scratch, removing its contents", projectPath),
+				IsConfirm: true,
+			}
+			if _, err := confirm.Run(); err != nil {
+				color.Yellow("Init cancelled.")
+				return nil
+			}
+		}
+
+		fmt.Println(color.CyanString("Backing up existing directory..."))
+		backup, err := backupDirectory(projectPath)
 		if err != nil {
-			color.Yellow("Project initialization cancelled.")
-			return nil
+			return err
 		}
+		color.Green("✓ Backup written to %s", backup)
 
 		if err := os.RemoveAll(projectPath); err != nil {
 			return fmt.Errorf("failed to remove existing directory: %w", err)
 		}
+	} else if exists {
+		color.Yellow("Directory %s already exists. Merging: only missing folders and files are created.", projectName)
 	}
+	merging := exists && !initForce
 
 	// Create project structure
 	fmt.Println(color.CyanString("Creating project structure..."))
-	if err := project.CreateStructure(projectPath, customerName); err != nil {
+	if err := project.CreateStructure(projectPath, layout, customer.Dir); err != nil {
 		return fmt.Errorf("failed to create project structure: %w", err)
 	}
 
 	// Fetch prompts from GitHub
 	fmt.Println(color.CyanString("Fetching base prompts from GitHub..."))
-	if err := github.FetchAndSavePrompts(projectPath); err != nil {
+	promptFilter := profilePromptFilter(profile)
+	if merging {
+		promptFilter = skipExistingPrompts(projectPath, promptFilter)
+	}
+	promptVersions, err := github.FetchAndSavePrompts(projectPath, promptFilter)
+	if err != nil {
 		return fmt.Errorf("failed to fetch prompts: %w", err)
 	}
 
 	// Fetch communication templates
 	fmt.Println(color.CyanString("Fetching communication templates..."))
-	if err := github.FetchCommunicationTemplates(projectPath); err != nil {
+	if err := github.FetchCommunicationTemplates(filepath.Join(projectPath, layout.RoleDir(project.RoleCommunicationTemplates))); err != nil {
 		color.Yellow("\nWarning: Failed to fetch some templates")
 	}
 
-	// Create project files
-	if err := project.CreateProjectFiles(projectPath, projectName, customerName); err != nil {
+	// Create project files, keeping any that already exist
+	if _, err := project.WriteFiles(projectPath, starterFiles); err != nil {
 		return fmt.Errorf("failed to create project files: %w", err)
 	}
 
-	color.Green("✓ Project \"%s\" created successfully!\n", projectName)
+	// Record the project manifest, merging into an existing one
+	manifest, err := project.LoadManifest(projectPath)
+	if errors.Is(err, project.ErrNotProject) {
+		manifest = project.NewManifest(projectPath, projectName)
+	} else if err != nil {
+		return err
+	}
+	if manifest.FindCustomer(customer.Dir) < 0 {
+		manifest.Customers = append(manifest.Customers, customer)
+	}
+	if ownerName != "" || manifest.Owner == "" {
+		manifest.Owner = owner
+	}
+	if manifest.Stage == "" || cmd.Flags().Changed("stage") {
+		manifest.Stage = strings.ToLower(stageName)
+	}
+	if manifest.Profile == "" || cmd.Flags().Changed("profile") {
+		manifest.Profile = profile.Name
+	}
+	if manifest.Prompts == nil {
+		manifest.Prompts = map[string]string{}
+	}
+	for name, sha := range promptVersions {
+		manifest.Prompts[name] = sha
+	}
+	if !merging || !project.FileExists(projectPath, filepath.Join(project.MetaDir, project.LayoutSnapshotFile)) {
+		if err := manifest.SaveLayoutSnapshot(layout); err != nil {
+			return fmt.Errorf("failed to record project layout: %w", err)
+		}
+	}
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+	registerProject(manifest)
+
+	color.Green("✓ Project \"%s\" created successfully with the %s profile!\n", projectName, profile.Name)
 
 	// Create GitHub repository if not skipped
 	githubToken := os.Getenv("GITHUB_PAT")
-	if !noGitHub && githubToken != "" {
+	if merging && project.FileExists(projectPath, ".git") {
+		fmt.Println("\nExisting git repository found. Skipped GitHub repository creation.")
+	} else if !noGitHub && githubToken != "" {
 		fmt.Println(color.CyanString("Creating GitHub repository..."))
 		if err := github.CreateRepository(projectPath, projectName, customerName); err != nil {
 			color.Red("✗ GitHub repository creation failed: %v", err)
@@ -129,11 +267,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	color.Cyan("Project structure created:")
 	fmt.Printf("  %s/\n", projectPath)
-	fmt.Println("  ├── 00_Inbox/")
-	fmt.Printf("  ├── 01_Customers/%s/\n", customerName)
-	fmt.Println("  ├── 10_PromptTemplates/")
-	fmt.Println("  ├── 20_Demo_Library/")
-	fmt.Println("  └── 99_Assets/")
+	for _, line := range layout.Tree(customer.Dir) {
+		fmt.Printf("  %s\n", line)
+	}
 
 	fmt.Println()
 	color.Yellow("Next steps:")
@@ -143,3 +279,116 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// profilePromptFilter selects the base prompts belonging to a profile's
+// prompt set using their catalog metadata
+func profilePromptFilter(profile *project.Profile) func(string, []byte) bool {
+	if profile.Prompts.IsEmpty() {
+		return nil
+	}
+	return func(name string, content []byte) bool {
+		meta, _, err := prompts.ParseMetadata(string(content))
+		if err != nil {
+			return true
+		}
+		return profile.Prompts.Includes(name, meta.Tags, meta.Stage, meta.Product)
+	}
+}
+
+func isKnownStage(stage string) bool {
+	for _, known := range prompts.Stages {
+		if strings.EqualFold(known, stage) {
+			return true
+		}
+	}
+	return false
+}
+
+// initAction is a single step of the init plan
+type initAction struct {
+	Symbol string
+	Text   string
+}
+
+func planInit(projectPath string, layout *project.Layout, customer project.Customer, files []project.File, exists bool) []initAction {
+	var plan []initAction
+	add := func(symbol, format string, args ...interface{}) {
+		plan = append(plan, initAction{Symbol: symbol, Text: fmt.Sprintf(format, args...)})
+	}
+
+	merging := exists && !initForce
+	switch {
+	case exists && initForce:
+		add("!", "back up %s to %s", projectPath, backupName(projectPath, time.Now()))
+		add("!", "remove %s and recreate it", projectPath)
+	case exists:
+		add("~", "merge into existing directory %s", projectPath)
+	default:
+		add("+", "create directory %s", projectPath)
+	}
+
+	dirs := project.MissingDirectories(projectPath, layout, customer.Dir)
+	if !merging {
+		dirs = layout.Paths(customer.Dir)
+	}
+	for _, dir := range dirs {
+		add("+", "mkdir  %s/", filepath.ToSlash(dir))
+	}
+
+	if merging {
+		add("+", "fetch  base prompts into %s/ (existing prompt files are kept)", prompts.TemplatesDir)
+	} else {
+		add("+", "fetch  base prompts into %s/", prompts.TemplatesDir)
+	}
+	add("+", "fetch  communication templates")
+
+	for _, file := range files {
+		if merging && project.FileExists(projectPath, file.Path) {
+			add("=", "keep   %s (exists)", file.Path)
+		} else {
+			add("+", "write  %s", file.Path)
+		}
+	}
+
+	manifestPath := filepath.ToSlash(filepath.Join(project.MetaDir, project.ManifestFile))
+	if merging && project.FileExists(projectPath, manifestPath) {
+		add("~", "update %s", manifestPath)
+	} else {
+		add("+", "write  %s", manifestPath)
+	}
+	return plan
+}
+
+func printInitPlan(plan []initAction) {
+	color.Cyan("Dry run: no changes made. Planned actions:")
+	for _, action := range plan {
+		fmt.Printf("  %s %s\n", action.Symbol, action.Text)
+	}
+}
+
+// backupName returns the archive path used to back up a directory
+func backupName(dir string, at time.Time) string {
+	clean := filepath.Clean(dir)
+	return filepath.Join(filepath.Dir(clean), fmt.Sprintf("%s-backup-%s.tar.gz", filepath.Base(clean), at.Format("20060102-150405")))
+}
+
+// backupDirectory archives a directory next to itself and returns the archive
+// path
+func backupDirectory(dir string) (string, error) {
+	dest := backupName(dir, time.Now())
+	if err := archive.WriteTarGz(dest, dir); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", dir, err)
+	}
+	return dest, nil
+}
+
+// skipExistingPrompts wraps a prompt filter so prompts already present in the
+// project are left alone
+func skipExistingPrompts(projectPath string, include func(string, []byte) bool) func(string, []byte) bool {
+	return func(name string, content []byte) bool {
+		if project.FileExists(projectPath, filepath.Join(prompts.TemplatesDir, name)) {
+			return false
+		}
+		return include == nil || include(name, content)
+	}
+}
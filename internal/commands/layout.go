@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var layoutYAML bool
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Show the effective project layout",
+	Long: `Shows the project layout used by "now-sc init" and where it was loaded from.
+Overrides are read from ~/.config/now-sc/layout.yaml, then from layout.yaml in
+$NOW_SC_ORG_DIR, before falling back to the built-in layout.`,
+	Args: cobra.NoArgs,
+	RunE: runLayout,
+}
+
+func init() {
+	layoutCmd.Flags().BoolVar(&layoutYAML, "yaml", false, "Print the layout as YAML, e.g. to start an override file")
+}
+
+func runLayout(cmd *cobra.Command, args []string) error {
+	layout, err := project.LoadLayout()
+	if err != nil {
+		return err
+	}
+
+	if layoutYAML {
+		out, err := yaml.Marshal(layout)
+		if err != nil {
+			return fmt.Errorf("failed to encode layout: %w", err)
+		}
+		fmt.Print(string(out))
+		return nil
+	}
+
+	color.Cyan("Layout version %d (%s)", layout.Version, layout.Source)
+	fmt.Println("  project-name/")
+	for _, line := range layout.Tree("[CustomerName]") {
+		fmt.Printf("  %s\n", line)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	setStage string
+	setOwner string
+)
+
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Show or update the project manifest",
+	Long: `The project manifest (.now-sc/project.yaml) records the project's customers, owner,
+stage, profile, prompt versions and the CLI and layout versions that created it.`,
+}
+
+var manifestShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the project manifest",
+	Args:  cobra.NoArgs,
+	RunE:  runManifestShow,
+}
+
+var manifestSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Update the project stage or owner",
+	Args:  cobra.NoArgs,
+	RunE:  runManifestSet,
+}
+
+func init() {
+	manifestSetCmd.Flags().StringVar(&setStage, "stage", "", "Sales stage ("+strings.Join(prompts.Stages, ", ")+")")
+	manifestSetCmd.Flags().StringVar(&setOwner, "owner", "", "Project owner")
+
+	manifestCmd.AddCommand(manifestShowCmd)
+	manifestCmd.AddCommand(manifestSetCmd)
+}
+
+func runManifestShow(cmd *cobra.Command, args []string) error {
+	manifest, err := loadProject()
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("%s", manifest.Project)
+	fmt.Printf("  Root:           %s\n", manifest.Root())
+	fmt.Printf("  Customers:      %s\n", strings.Join(manifest.CustomerNames(), ", "))
+	fmt.Printf("  Owner:          %s\n", manifest.Owner)
+	fmt.Printf("  Stage:          %s\n", manifest.Stage)
+	fmt.Printf("  Profile:        %s\n", manifest.Profile)
+	fmt.Printf("  Created:        %s\n", manifest.Created.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("  Updated:        %s\n", manifest.Updated.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("  CLI version:    %s\n", manifest.CLIVersion)
+	fmt.Printf("  Layout version: %d\n", manifest.LayoutVersion)
+	fmt.Printf("  Base prompts:   %d\n", len(manifest.Prompts))
+	return nil
+}
+
+func runManifestSet(cmd *cobra.Command, args []string) error {
+	if setStage == "" && setOwner == "" {
+		return fmt.Errorf("nothing to update: pass --stage or --owner")
+	}
+
+	manifest, err := loadProject()
+	if err != nil {
+		return err
+	}
+
+	if setStage != "" {
+		if !isKnownStage(setStage) {
+			return fmt.Errorf("unknown stage %q (expected one of %s)", setStage, strings.Join(prompts.Stages, ", "))
+		}
+		manifest.Stage = strings.ToLower(setStage)
+	}
+	if setOwner != "" {
+		manifest.Owner = setOwner
+	}
+
+	if err := manifest.Save(); err != nil {
+		return err
+	}
+	color.Green("✓ Manifest updated")
+	return nil
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/archive"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateDryRun   bool
+	migrateRollback string
+	migrateYes      bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade a project to the current layout version",
+	Long: `Brings a project created by an older version of now-sc up to the current
+layout version, as recorded in .now-sc/project.yaml. Migrations create missing
+folders, move or rename folders, rewrite relative Markdown links that point into
+moved folders and record the new layout version. Projects created before the
+manifest existed are adopted and get a manifest.
+
+The project is archived next to itself before anything changes, and restored
+automatically if a migration fails. Undo a completed migration with
+--rollback <archive>; the archive must be a backup of this project, and
+everything changed since it was made is lost.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Print the migration plan without changing anything")
+	migrateCmd.Flags().StringVar(&migrateRollback, "rollback", "", "Restore the project from a migration archive")
+	migrateCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, "Do not ask for confirmation before a rollback")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	start, err := projectStart()
+	if err != nil {
+		return err
+	}
+	root, err := project.FindMigrationRoot(start)
+	if err != nil {
+		return err
+	}
+
+	if migrateRollback != "" {
+		confirm := func() bool {
+			if migrateYes {
+				return true
+			}
+			prompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Replace %s with %s, losing changes made since", root, migrateRollback),
+				IsConfirm: true,
+			}
+			_, err := prompt.Run()
+			return err == nil
+		}
+		restored, err := restoreDirectory(migrateRollback, root, confirm)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			color.Yellow("Rollback cancelled.")
+			return nil
+		}
+		color.Green("✓ Restored %s from %s", root, migrateRollback)
+		return nil
+	}
+
+	plan, err := project.PlanMigration(root)
+	if err != nil {
+		return err
+	}
+	if plan.UpToDate() {
+		color.Green("✓ Project is at layout version %d, nothing to migrate", plan.From)
+		return nil
+	}
+
+	printMigrationPlan(plan)
+	if migrateDryRun {
+		fmt.Println()
+		color.Yellow("Dry run: no changes made.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(color.CyanString("Backing up project..."))
+	backup, err := backupDirectory(root)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := plan.Apply()
+	if err != nil {
+		color.Red("✗ Migration failed: %v", err)
+		fmt.Println(color.CyanString("Restoring project from backup..."))
+		if _, restoreErr := restoreDirectory(backup, root, nil); restoreErr != nil {
+			return fmt.Errorf("migration failed (%v) and restore failed: %w; the backup is %s", err, restoreErr, backup)
+		}
+		return fmt.Errorf("migration failed, project restored: %w", err)
+	}
+
+	if plan.Legacy() {
+		registerProject(manifest)
+	}
+	color.Green("✓ Project migrated to layout version %d", plan.To)
+	fmt.Printf("Rollback archive: %s\n", backup)
+	fmt.Printf("Undo with: now-sc migrate --rollback %s\n", backup)
+	return nil
+}
+
+func printMigrationPlan(plan *project.MigrationPlan) {
+	color.Cyan("Migrating %s from layout version %d to %d", plan.Root, plan.From, plan.To)
+	for _, migration := range plan.Migrations {
+		fmt.Println()
+		fmt.Printf("v%d: %s\n", migration.Version, migration.Description)
+		for _, step := range migration.Steps {
+			fmt.Printf("  + %s\n", step.Describe())
+		}
+	}
+
+	if len(plan.Links) > 0 {
+		fmt.Println()
+		files := make([]string, 0, len(plan.Links))
+		for file := range plan.Links {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			fmt.Printf("  ~ rewrite %d link(s) in %s\n", plan.Links[file], file)
+		}
+	}
+
+	fmt.Println()
+	manifestPath := filepath.ToSlash(filepath.Join(project.MetaDir, project.ManifestFile))
+	if plan.Legacy() {
+		fmt.Printf("  + write  %s (layout version %d)\n", manifestPath, plan.To)
+	} else {
+		fmt.Printf("  ~ record layout version %d in %s\n", plan.To, manifestPath)
+	}
+}
+
+// restoreDirectory replaces dir with the contents of a backup made by
+// backupDirectory, once the backup is verified to be of the same project.
+// confirm, when set, is asked before anything is removed; restoreDirectory
+// reports whether the project was restored.
+func restoreDirectory(backup, dir string, confirm func() bool) (bool, error) {
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".now-sc-restore-")
+	if err != nil {
+		return false, fmt.Errorf("failed to restore: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := archive.ExtractTarGz(backup, tmp); err != nil {
+		return false, err
+	}
+
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore: %w", err)
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return false, fmt.Errorf("%s is not a project backup", backup)
+	}
+	restored := filepath.Join(tmp, entries[0].Name())
+	if err := project.VerifyBackup(restored, dir); err != nil {
+		return false, fmt.Errorf("%s cannot be restored: %w", backup, err)
+	}
+	if confirm != nil && !confirm() {
+		return false, nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return false, fmt.Errorf("failed to remove %s: %w", dir, err)
+	}
+	if err := os.Rename(restored, dir); err != nil {
+		return false, fmt.Errorf("failed to restore %s: %w", dir, err)
+	}
+	return true, nil
+}
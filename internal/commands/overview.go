@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/history"
+	"github.com/Now-AI-Foundry/Now-SC/internal/overview"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	overviewProvider string
+	overviewModel    string
+	overviewTemplate string
+	overviewDryRun   bool
+)
+
+var overviewCmd = &cobra.Command{
+	Use:   "overview",
+	Short: "Keep the project overview up to date",
+	Long: `Maintains a living project overview in Project_Overview.md in the layout's
+overview folder (99_Assets/Project_Overview by default). Each update sends the
+current overview and only the inbox material that is new or changed since the
+last update to the model, which returns the revised document. Prior versions and
+the diff of every update are kept in its versions folder.`,
+}
+
+var overviewUpdateCmd = &cobra.Command{
+	Use:          "update",
+	Short:        "Fold new inbox material into the project overview",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runOverviewUpdate,
+}
+
+var overviewStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the overview version and the material not yet incorporated",
+	Args:  cobra.NoArgs,
+	RunE:  runOverviewStatus,
+}
+
+func init() {
+	overviewUpdateCmd.Flags().StringVar(&overviewProvider, "provider", provider.OpenRouter, "Provider updating the overview (openrouter, fake)")
+	overviewUpdateCmd.Flags().StringVar(&overviewModel, "model", "", "Model updating the overview")
+	overviewUpdateCmd.Flags().StringVar(&overviewTemplate, "template", "", "Prompt template in 10_PromptTemplates to use instead of the built-in one")
+	overviewUpdateCmd.Flags().BoolVar(&overviewDryRun, "dry-run", false, "List the material that would be sent without calling the model")
+
+	overviewCmd.AddCommand(overviewUpdateCmd)
+	overviewCmd.AddCommand(overviewStatusCmd)
+}
+
+func runOverviewUpdate(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+	state, err := overview.LoadState(manifest)
+	if err != nil {
+		return err
+	}
+	pending, err := pendingMaterial(manifest, layout, state)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		color.Green("✓ The overview is up to date (version %d)", state.Version)
+		return nil
+	}
+
+	fmt.Printf("New material since version %d:\n", state.Version)
+	for _, material := range pending {
+		fmt.Printf("  %s\n", material.File)
+	}
+	if overviewDryRun {
+		return nil
+	}
+
+	var system string
+	if overviewTemplate != "" {
+		templatesDir := manifest.Path(prompts.TemplatesDir)
+		if system, err = prompts.NewRenderer(templatesDir).RenderFile(filepath.Join(templatesDir, overviewTemplate)); err != nil {
+			return err
+		}
+	}
+	p, err := provider.New(overviewProvider, overviewModel)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(color.CyanString("Updating the overview..."))
+	result, err := overview.Update(p, manifest, layout, state, pending, system)
+	if err != nil {
+		return fmt.Errorf("failed to update the overview: %w", err)
+	}
+
+	color.Green("✓ %s updated to version %d with %d file(s)", overview.File(layout), result.Version, len(result.Incorporated))
+	template := "<overview>"
+	if overviewTemplate != "" {
+		template = filepath.Join(prompts.TemplatesDir, overviewTemplate)
+	}
+	if err := history.RecordRun(manifest, history.CommandOverview, template, p.Name(), p.Model(), overview.File(layout), result.Incorporated); err != nil {
+		color.Yellow("Warning: could not record the run in the history: %v", err)
+	}
+	if len(result.Deferred) > 0 {
+		color.Yellow("  %d file(s) did not fit and are left for the next update", len(result.Deferred))
+	}
+	if result.Previous != "" {
+		fmt.Printf("Previous version kept as %s\n", result.Previous)
+		if result.Diff == "" {
+			fmt.Println("The overview did not change.")
+		} else {
+			fmt.Println()
+			printDiff(result.Diff)
+		}
+	}
+	return nil
+}
+
+func runOverviewStatus(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+	state, err := overview.LoadState(manifest)
+	if err != nil {
+		return err
+	}
+	pending, err := pendingMaterial(manifest, layout, state)
+	if err != nil {
+		return err
+	}
+
+	if state.Version == 0 {
+		fmt.Println("No overview yet; run \"now-sc overview update\" to write one.")
+	} else {
+		fmt.Printf("Version %d, updated %s with %s, incorporating %d file(s)\n",
+			state.Version, state.Updated.Local().Format("2006-01-02 15:04"), orDash(state.Model), len(state.Incorporated))
+	}
+	if len(pending) == 0 {
+		color.Green("✓ No new material")
+		return nil
+	}
+	color.Yellow("%d file(s) not yet incorporated:", len(pending))
+	for _, material := range pending {
+		fmt.Printf("  %s\n", material.File)
+	}
+	return nil
+}
+
+// pendingMaterial lists the material not yet in the overview, warning about
+// files that cannot be read as text
+func pendingMaterial(manifest *project.Manifest, layout *project.Layout, state *overview.State) ([]overview.Material, error) {
+	pending, skipped, err := overview.Pending(manifest, layout, state)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range skipped {
+		color.Yellow("Warning: skipped %s", problem)
+	}
+	return pending, nil
+}
+
+// printDiff prints a unified diff with additions and removals in color
+func printDiff(changes string) {
+	for _, line := range strings.Split(strings.TrimRight(changes, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Println(color.New(color.Bold).Sprint(line))
+		case strings.HasPrefix(line, "+"):
+			color.Green(line)
+		case strings.HasPrefix(line, "-"):
+			color.Red(line)
+		case strings.HasPrefix(line, "@@"):
+			color.Cyan(line)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Manage project profiles",
+	Long: `Profiles bundle a layout, a prompt set and seed files for one type of engagement.
+They are read from ~/.config/now-sc/profiles, $NOW_SC_ORG_DIR/profiles and the
+profiles folder of each template source, before the built-in profiles.`,
+}
+
+var profilesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available project profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfilesList,
+}
+
+var profilesShowCmd = &cobra.Command{
+	Use:   "show <profile>",
+	Short: "Show the layout and prompt set of a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfilesShow,
+}
+
+func init() {
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesShowCmd)
+}
+
+func runProfilesList(cmd *cobra.Command, args []string) error {
+	profiles, err := project.ListProfiles()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROFILE\tDESCRIPTION\tSOURCE")
+	for _, profile := range profiles {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", profile.Name, profile.Description, profile.Source)
+	}
+	return w.Flush()
+}
+
+func runProfilesShow(cmd *cobra.Command, args []string) error {
+	profile, err := project.LoadProfile(args[0])
+	if err != nil {
+		return err
+	}
+
+	layout, err := profile.ResolveLayout()
+	if err != nil {
+		return err
+	}
+
+	color.Cyan("%s - %s", profile.Name, profile.Description)
+	fmt.Printf("Source: %s\n\n", profile.Source)
+
+	fmt.Println("Layout:")
+	for _, line := range layout.Tree("[CustomerName]") {
+		fmt.Printf("  %s\n", line)
+	}
+
+	fmt.Println()
+	fmt.Println("Prompts:")
+	set := profile.Prompts
+	if set.IsEmpty() {
+		fmt.Println("  all base prompts")
+	}
+	printList := func(label string, values []string) {
+		if len(values) > 0 {
+			fmt.Printf("  %-9s %v\n", label+":", values)
+		}
+	}
+	printList("names", set.Names)
+	printList("products", set.Products)
+	printList("stages", set.Stages)
+	printList("tags", set.Tags)
+
+	if len(profile.Files) > 0 {
+		fmt.Println()
+		fmt.Println("Seed files:")
+		for _, file := range profile.Files {
+			if file.Template != "" {
+				fmt.Printf("  %s (from %s)\n", file.Path, file.Template)
+			} else {
+				fmt.Printf("  %s\n", file.Path)
+			}
+		}
+	}
+	return nil
+}
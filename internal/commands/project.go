@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/workspace"
+	"github.com/fatih/color"
+)
+
+// projectRef selects another workspace project than the one containing the
+// working directory
+var projectRef string
+
+// projectStart returns the directory commands look for their project from:
+// the --project selection or the working directory
+func projectStart() (string, error) {
+	if projectRef == "" {
+		return ".", nil
+	}
+	ws, err := workspace.Load()
+	if err != nil {
+		return "", err
+	}
+	entry, err := ws.Find(projectRef)
+	if err != nil {
+		return "", err
+	}
+	return entry.Path, nil
+}
+
+// loadProject finds and loads the manifest of the project containing the
+// working directory, walking up parent directories, or of the project
+// selected with --project
+func loadProject() (*project.Manifest, error) {
+	start, err := projectStart()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := project.Find(start)
+	if err != nil {
+		if errors.Is(err, project.ErrNotProject) {
+			color.Red("Error: %v", err)
+			if _, legacyErr := project.FindLegacyRoot(start); legacyErr == nil {
+				color.Yellow("This project was created by an older version of now-sc. Run \"now-sc migrate\" to upgrade it.")
+			} else {
+				color.Yellow("Make sure you are in a project created with \"now-sc init\"")
+			}
+		}
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// findPromptsPath loads the current project and returns its prompt templates
+// directory
+func findPromptsPath() (*project.Manifest, string, error) {
+	manifest, err := loadProject()
+	if err != nil {
+		return nil, "", err
+	}
+	return manifest, manifest.Path(prompts.TemplatesDir), nil
+}
+
+// loadProjectLayout loads the current project and the layout it was created
+// with
+func loadProjectLayout() (*project.Manifest, *project.Layout, error) {
+	manifest, err := loadProject()
+	if err != nil {
+		return nil, nil, err
+	}
+	layout, err := manifest.LoadLayout()
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, layout, nil
+}
+
+// registerProject records a project in the workspace so it shows up in
+// "now-sc projects list" wherever it lives
+func registerProject(manifest *project.Manifest) {
+	ws, err := workspace.Load()
+	if err == nil {
+		if err = ws.Register(manifest.Project, manifest.Root()); err == nil {
+			err = ws.Save()
+		}
+	}
+	if err != nil {
+		color.Yellow("Warning: could not register the project in the workspace: %v", err)
+	}
+}
+
+// relPath joins path elements and makes the result relative to the working
+// directory when possible, for display
+func relPath(elem ...string) string {
+	path := filepath.Join(elem...)
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			return rel
+		}
+	}
+	return path
+}
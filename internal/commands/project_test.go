@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/workspace"
+)
+
+func TestProjectFlag(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv(workspace.RootEnv, filepath.Join(home, "projects"))
+
+	acme := filepath.Join(home, "projects", "acme")
+	initech := filepath.Join(home, "clients", "initech")
+	for dir, name := range map[string]string{acme: "Acme POC", initech: "Initech"} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := project.NewManifest(dir, name).Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ws, err := workspace.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws.Register("initech", initech)
+	if err := ws.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { projectRef = "" }()
+	for ref, want := range map[string]string{"acme poc": acme, "initech": initech, initech: initech} {
+		projectRef = ref
+		manifest, err := loadProject()
+		if err != nil {
+			t.Fatalf("--project %s: %v", ref, err)
+		}
+		if manifest.Root() != want {
+			t.Errorf("--project %s loaded %s, want %s", ref, manifest.Root(), want)
+		}
+	}
+
+	projectRef = "globex"
+	if _, err := loadProject(); err == nil {
+		t.Error("--project accepted an unknown project")
+	}
+}
@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/workspace"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var projectsOpenPrint bool
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Work with all projects in the workspace",
+	Long: `The workspace is every project under the workspace root plus projects registered
+from elsewhere. Projects are registered automatically by "now-sc init".
+
+The workspace is stored in ~/.config/now-sc/workspace.yaml:
+
+  root: ~/presales
+  projects:
+    - name: acme-itsm
+      path: /home/jane/deals/acme-itsm
+
+Set NOW_SC_WORKSPACE to override the root. Any project command can run against
+a workspace project with --project <name>.`,
+}
+
+var projectsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspace projects with their customer, stage and activity",
+	Args:  cobra.NoArgs,
+	RunE:  runProjectsList,
+}
+
+var projectsOpenCmd = &cobra.Command{
+	Use:   "open <name>",
+	Short: "Open a shell in a workspace project",
+	Long: `Starts $SHELL in the project's folder; exit the shell to return. With --print,
+prints the folder instead, e.g. cd "$(now-sc projects open acme --print)".`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runProjectsOpen,
+}
+
+var projectsAddCmd = &cobra.Command{
+	Use:   "add [path]",
+	Short: "Register a project outside the workspace root",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProjectsAdd,
+}
+
+var projectsRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister a project (its files are kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProjectsRemove,
+}
+
+var projectsRootCmd = &cobra.Command{
+	Use:   "root [dir]",
+	Short: "Show or set the workspace root",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProjectsRoot,
+}
+
+func init() {
+	projectsOpenCmd.Flags().BoolVar(&projectsOpenPrint, "print", false, "Print the project folder instead of starting a shell")
+
+	projectsCmd.AddCommand(projectsListCmd)
+	projectsCmd.AddCommand(projectsOpenCmd)
+	projectsCmd.AddCommand(projectsAddCmd)
+	projectsCmd.AddCommand(projectsRemoveCmd)
+	projectsCmd.AddCommand(projectsRootCmd)
+}
+
+func runProjectsList(cmd *cobra.Command, args []string) error {
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+
+	entries := ws.Entries()
+	if len(entries) == 0 {
+		color.Yellow("No projects. Set a workspace root with \"now-sc projects root <dir>\" or create one with \"now-sc init\".")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tCUSTOMER\tSTAGE\tLAST ACTIVITY\tOPEN ACTIONS\tPATH")
+	for _, entry := range entries {
+		if entry.Manifest == nil {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t%s (%s)\n", entry.Name, entry.Path, missingReason(entry.Err))
+			continue
+		}
+
+		summary := workspace.Summarize(entry.Path)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			entry.Name,
+			strings.Join(entry.Manifest.CustomerNames(), ", "),
+			entry.Manifest.Stage,
+			formatActivity(summary.LastActivity),
+			summary.OpenActions,
+			relPath(entry.Path))
+	}
+	return w.Flush()
+}
+
+func runProjectsOpen(cmd *cobra.Command, args []string) error {
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+	entry, err := ws.Find(args[0])
+	if err != nil {
+		return err
+	}
+
+	if projectsOpenPrint {
+		fmt.Println(entry.Path)
+		return nil
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+		if runtime.GOOS == "windows" {
+			shell = os.Getenv("COMSPEC")
+		}
+	}
+
+	color.Cyan("Opening %s in %s (exit the shell to return)", entry.Name, entry.Path)
+	sh := exec.Command(shell)
+	sh.Dir = entry.Path
+	sh.Stdin, sh.Stdout, sh.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return sh.Run()
+}
+
+func runProjectsAdd(cmd *cobra.Command, args []string) error {
+	path := "."
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	manifest, err := project.Find(path)
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+	if err := ws.Register(manifest.Project, manifest.Root()); err != nil {
+		return err
+	}
+	if err := ws.Save(); err != nil {
+		return err
+	}
+
+	color.Green("✓ Project \"%s\" registered", manifest.Project)
+	return nil
+}
+
+func runProjectsRemove(cmd *cobra.Command, args []string) error {
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+
+	ref := args[0]
+	if entry, err := ws.Find(ref); err == nil {
+		ref = entry.Path
+	}
+	if !ws.Unregister(ref) {
+		return fmt.Errorf("project %q is not registered", args[0])
+	}
+	if err := ws.Save(); err != nil {
+		return err
+	}
+
+	color.Green("✓ Project \"%s\" unregistered", args[0])
+	return nil
+}
+
+func runProjectsRoot(cmd *cobra.Command, args []string) error {
+	ws, err := workspace.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		if root := ws.RootDir(); root != "" {
+			fmt.Println(root)
+		} else {
+			color.Yellow("No workspace root set. Set one with \"now-sc projects root <dir>\".")
+		}
+		return nil
+	}
+
+	info, err := os.Stat(args[0])
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", args[0])
+	}
+	if ws.Root, err = filepath.Abs(args[0]); err != nil {
+		return err
+	}
+	if err := ws.Save(); err != nil {
+		return err
+	}
+
+	color.Green("✓ Workspace root set to %s", ws.Root)
+	return nil
+}
+
+func formatActivity(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	days := int(time.Since(t).Hours() / 24)
+	switch {
+	case days <= 0:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	case days < 30:
+		return fmt.Sprintf("%d days ago", days)
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+func missingReason(err error) string {
+	if err == nil {
+		return "unavailable"
+	}
+	if errors.Is(err, project.ErrNotProject) {
+		return "missing"
+	}
+	return "invalid manifest"
+}
@@ -7,20 +7,35 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+	"github.com/Now-AI-Foundry/Now-SC/internal/history"
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
+var promptAttach []string
+
 var promptCmd = &cobra.Command{
 	Use:   "prompt",
 	Short: "Execute a prompt template",
 	Long: `Execute a prompt template using OpenRouter API.
-Requires OPENROUTER_API_KEY environment variable to be set.`,
+Requires OPENROUTER_API_KEY environment variable to be set.
+
+Attach files as context with --attach. Transcripts (WebVTT, SRT, Teams, Zoom)
+are converted to compact speaker turns, .eml/.mbox files to threads, and PDF,
+Word, PowerPoint and Excel files to Markdown (cached next to them) first.`,
 	RunE: runPrompt,
 }
 
+func init() {
+	promptCmd.Flags().StringArrayVar(&promptAttach, "attach", nil, "Attach a file as context (repeatable)")
+}
+
 func runPrompt(cmd *cobra.Command, args []string) error {
 	// Check for API key
 	apiKey := os.Getenv("OPENROUTER_API_KEY")
@@ -31,61 +46,36 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("OPENROUTER_API_KEY not set")
 	}
 
-	// Find prompt templates directory
-	promptsPath := filepath.Join(".", "10_PromptTemplates")
-	if _, err := os.Stat(promptsPath); os.IsNotExist(err) {
-		color.Red("Error: No prompt templates directory found in current directory")
-		color.Yellow("Make sure you are in a project created with \"now-sc init\"")
-		return fmt.Errorf("prompt templates directory not found")
-	}
-
-	// List available prompts
-	entries, err := os.ReadDir(promptsPath)
+	attachments, err := ingest.ReadAttachments(promptAttach)
 	if err != nil {
-		return fmt.Errorf("failed to read prompts directory: %w", err)
-	}
-
-	var promptFiles []string
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
-			promptFiles = append(promptFiles, entry.Name())
-		}
+		return err
 	}
 
-	if len(promptFiles) == 0 {
-		color.Red("Error: No prompt templates found")
-		return fmt.Errorf("no prompt templates found")
+	// Find prompt templates directory
+	manifest, promptsPath, err := findPromptsPath()
+	if err != nil {
+		return err
 	}
 
 	// Let user select a prompt
-	templates := make([]string, len(promptFiles))
-	for i, file := range promptFiles {
-		templates[i] = strings.TrimSuffix(strings.ReplaceAll(file, "_", " "), ".md")
-	}
-
-	promptSelect := promptui.Select{
-		Label: "Select a prompt template",
-		Items: templates,
-	}
-
-	idx, _, err := promptSelect.Run()
+	selected, err := selectTemplate(promptsPath)
 	if err != nil {
-		return fmt.Errorf("prompt selection failed: %w", err)
+		return err
 	}
 
-	selectedPrompt := promptFiles[idx]
+	selectedPrompt := selected.Filename
 
-	// Read the prompt content
-	promptContent, err := os.ReadFile(filepath.Join(promptsPath, selectedPrompt))
+	// Read the prompt content and expand includes
+	promptContent, err := prompts.NewRenderer(promptsPath).RenderFile(selected.Path)
 	if err != nil {
-		return fmt.Errorf("failed to read prompt file: %w", err)
+		return fmt.Errorf("failed to render prompt: %w", err)
 	}
 
 	// Show prompt preview
 	fmt.Println()
 	color.Cyan("Prompt Preview:")
 	fmt.Println("─────────────────────────────────────────")
-	preview := string(promptContent)
+	preview := promptContent
 	if len(preview) > 200 {
 		preview = preview[:200] + "..."
 	}
@@ -104,11 +94,15 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 	fmt.Println(color.CyanString("Executing prompt..."))
 
 	// Execute prompt
-	client := openrouter.NewClient(apiKey)
-	result, err := client.ExecutePrompt(string(promptContent), userInput)
+	client, err := provider.New(provider.OpenRouter, "")
+	if err != nil {
+		return err
+	}
+	completion, err := provider.Complete(client, promptContent, ingest.WithAttachments(userInput, attachments))
 	if err != nil {
 		return fmt.Errorf("failed to execute prompt: %w", err)
 	}
+	result := completion.Content
 
 	color.Green("✓ Prompt executed successfully!\n")
 
@@ -133,13 +127,24 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 	}
 
 	// Select output location
-	locations := []string{
-		"Project Overview (99_Assets/Project_Overview)",
-		"Communications (99_Assets/Communications)",
-		"POC Documents (99_Assets/POC_Documents)",
-		"Notes (00_Inbox/notes)",
-		"Other (specify)",
+	layout, err := manifest.LoadLayout()
+	if err != nil {
+		return err
+	}
+	folders := []struct{ label, role string }{
+		{"Project Overview", project.RoleOverview},
+		{"Communications", project.RoleCommunications},
+		{"POC Documents", project.RoleDocuments},
+		{"Notes", project.RoleNotes},
+	}
+	var locations, paths []string
+	for _, folder := range folders {
+		if dir := layout.RoleDir(folder.role); dir != "" {
+			locations = append(locations, fmt.Sprintf("%s (%s)", folder.label, filepath.ToSlash(dir)))
+			paths = append(paths, dir)
+		}
 	}
+	locations = append(locations, "Other (specify)")
 
 	locationSelect := promptui.Select{
 		Label: "Where would you like to save the output?",
@@ -152,19 +157,12 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 	}
 
 	var savePath string
-	switch locIdx {
-	case 0:
-		savePath = "99_Assets/Project_Overview"
-	case 1:
-		savePath = "99_Assets/Communications"
-	case 2:
-		savePath = "99_Assets/POC_Documents"
-	case 3:
-		savePath = "00_Inbox/notes"
-	case 4:
+	if locIdx < len(paths) {
+		savePath = paths[locIdx]
+	} else {
 		promptCustom := promptui.Prompt{
 			Label:   "Enter the path (relative to project root)",
-			Default: "99_Assets",
+			Default: filepath.ToSlash(layout.RoleDir(project.RoleAssets)),
 		}
 		customPath, err := promptCustom.Run()
 		if err != nil {
@@ -191,38 +189,50 @@ func runPrompt(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Create output content
-	outputContent := fmt.Sprintf(`# %s
-
-**Date:** %s
-**Prompt Template:** %s
-**Model:** %s
-
-## User Input
-
-%s
-
-## Response
-
-%s
-`, strings.ReplaceAll(filename, "_", " "),
-		time.Now().Format("2006-01-02 15:04:05"),
-		selectedPrompt,
-		"google/gemini-2.0-flash-exp:free",
-		userInput,
-		result)
+	// Create output content with its provenance
+	title := strings.ReplaceAll(filename, "_", " ")
+	prov, err := newProvenance(manifest, title, selected.Path, promptContent, promptAttach, completion)
+	if err != nil {
+		return err
+	}
+	prov.Parameters.Input = userInput
+	outputContent, err := prov.Document(prov.Body(result))
+	if err != nil {
+		return err
+	}
 
 	// Save to file
-	fullPath := filepath.Join(".", savePath, filename+".md")
+	fullPath := manifest.Path(savePath, filename+".md")
 	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	if err := os.WriteFile(fullPath, []byte(outputContent), 0644); err != nil {
+	if err := os.WriteFile(fullPath, outputContent, 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	color.Green("✓ Output saved to: %s", fullPath)
+	if err := history.Record(manifest, history.CommandPrompt, fullPath, prov); err != nil {
+		color.Yellow("Warning: could not record the run in the history: %v", err)
+	}
+
+	return manifest.Save()
+}
+
+// newProvenance records how an output was generated from a template and
+// attached files
+func newProvenance(manifest *project.Manifest, title, templatePath, rendered string, files []string, completion *provider.Completion) (*provenance.Provenance, error) {
+	template, err := provenance.NewTemplate(manifest.Root(), templatePath, rendered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record template: %w", err)
+	}
+	inputs, err := provenance.NewInputs(manifest.Root(), files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record inputs: %w", err)
+	}
 
-	return nil
+	prov := provenance.New(manifest, title, provider.OpenRouter, completion)
+	prov.Template = template
+	prov.Inputs = inputs
+	return prov, nil
 }
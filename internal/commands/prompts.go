@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listTag     string
+	listStage   string
+	listProduct string
+)
+
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "Manage prompt templates",
+	Long:  `Inspect and manage the prompt templates in the current project.`,
+}
+
+var promptsRenderCmd = &cobra.Command{
+	Use:   "render <template>",
+	Short: "Print a fully expanded prompt template",
+	Long: `Expands {{ include "..." }} directives in a prompt template and prints the result.
+Partials are resolved from the _partials folder of 10_PromptTemplates and of any
+configured template sources (NOW_SC_TEMPLATE_SOURCES).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptsRender,
+}
+
+var promptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List prompt templates with their catalog metadata",
+	Long: `Lists the prompt templates in the current project using the title, description,
+tags, stage and product line declared in each template's front matter.`,
+	Args: cobra.NoArgs,
+	RunE: runPromptsList,
+}
+
+func init() {
+	promptsListCmd.Flags().StringVar(&listTag, "tag", "", "Only show templates with this tag")
+	promptsListCmd.Flags().StringVar(&listStage, "stage", "", "Only show templates for this stage ("+strings.Join(prompts.Stages, ", ")+")")
+	promptsListCmd.Flags().StringVar(&listProduct, "product", "", "Only show templates for this product line")
+
+	promptsCmd.AddCommand(promptsListCmd)
+	promptsCmd.AddCommand(promptsRenderCmd)
+}
+
+func runPromptsList(cmd *cobra.Command, args []string) error {
+	_, promptsPath, err := findPromptsPath()
+	if err != nil {
+		return err
+	}
+
+	catalog, err := loadCatalog(promptsPath)
+	if err != nil {
+		return err
+	}
+
+	filter := prompts.Filter{Tag: listTag, Stage: listStage, Product: listProduct}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TEMPLATE\tSTAGE\tPRODUCT\tTAGS\tDESCRIPTION")
+
+	matched := 0
+	for _, t := range catalog {
+		if !filter.Match(t) {
+			continue
+		}
+		matched++
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			t.Label(),
+			strings.Join(t.Stage, ","),
+			strings.Join(t.Product, ","),
+			strings.Join(t.Tags, ","),
+			t.Description)
+	}
+	w.Flush()
+
+	if matched == 0 {
+		color.Yellow("No templates match the given filters.")
+	}
+	return nil
+}
+
+// loadCatalog indexes the project's templates, warning about those skipped
+func loadCatalog(promptsPath string) ([]prompts.Template, error) {
+	catalog, skipped, err := prompts.LoadCatalog(promptsPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range skipped {
+		color.Yellow("Warning: skipped template %s", problem)
+	}
+	return catalog, nil
+}
+
+// selectTemplate shows a searchable template picker with descriptions
+func selectTemplate(promptsPath string) (prompts.Template, error) {
+	catalog, err := loadCatalog(promptsPath)
+	if err != nil {
+		return prompts.Template{}, err
+	}
+
+	if len(catalog) == 0 {
+		color.Red("Error: No prompt templates found")
+		return prompts.Template{}, fmt.Errorf("no prompt templates found")
+	}
+
+	promptSelect := promptui.Select{
+		Label: "Select a prompt template (type to search)",
+		Items: catalog,
+		Size:  10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "▸ {{ .Label | cyan }}",
+			Inactive: "  {{ .Label }}",
+			Selected: "✓ {{ .Label | green }}",
+			Details: `
+{{ "File:" | faint }}	{{ .Filename }}
+{{- if .Description }}
+{{ "Description:" | faint }}	{{ .Description }}
+{{- end }}
+{{- if .Stage }}
+{{ "Stage:" | faint }}	{{ join .Stage ", " }}
+{{- end }}
+{{- if .Tags }}
+{{ "Tags:" | faint }}	{{ join .Tags ", " }}
+{{- end }}`,
+			FuncMap: templateFuncs(),
+		},
+		Searcher: func(input string, index int) bool {
+			t := catalog[index]
+			return prompts.FuzzyMatch(input, t.Label()+" "+t.Description+" "+strings.Join(t.Tags, " "))
+		},
+		StartInSearchMode: true,
+	}
+
+	idx, _, err := promptSelect.Run()
+	if err != nil {
+		return prompts.Template{}, fmt.Errorf("prompt selection failed: %w", err)
+	}
+
+	return catalog[idx], nil
+}
+
+func templateFuncs() map[string]interface{} {
+	funcs := map[string]interface{}{}
+	for name, fn := range promptui.FuncMap {
+		funcs[name] = fn
+	}
+	funcs["join"] = func(list prompts.StringList, sep string) string {
+		return strings.Join(list, sep)
+	}
+	return funcs
+}
+
+func runPromptsRender(cmd *cobra.Command, args []string) error {
+	_, promptsPath, err := findPromptsPath()
+	if err != nil {
+		return err
+	}
+
+	templatePath, err := prompts.Find(promptsPath, args[0])
+	if err != nil {
+		return err
+	}
+
+	rendered, err := prompts.NewRenderer(promptsPath).RenderFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", args[0], err)
+	}
+
+	fmt.Println(rendered)
+	return nil
+}
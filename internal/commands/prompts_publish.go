@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/diff"
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	publishTitle   string
+	publishMessage string
+	publishBranch  string
+	publishDryRun  bool
+	publishYes     bool
+)
+
+var promptsPublishCmd = &cobra.Command{
+	Use:   "publish <template>",
+	Short: "Propose a local prompt improvement upstream",
+	Long: `Diffs a local prompt template against its upstream version in the base prompts
+repository, commits the change to a new branch (in a fork when you cannot push to
+the source repository) and opens a pull request.
+Requires GITHUB_PAT environment variable to be set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptsPublish,
+}
+
+func init() {
+	promptsPublishCmd.Flags().StringVar(&publishTitle, "title", "", "Pull request title")
+	promptsPublishCmd.Flags().StringVarP(&publishMessage, "message", "m", "", "Explanation of the change to include in the pull request")
+	promptsPublishCmd.Flags().StringVar(&publishBranch, "branch", "", "Branch name to create (default: now-sc/<template>-<timestamp>)")
+	promptsPublishCmd.Flags().BoolVar(&publishDryRun, "dry-run", false, "Show the diff without creating a pull request")
+	promptsPublishCmd.Flags().BoolVarP(&publishYes, "yes", "y", false, "Do not ask for confirmation")
+
+	promptsCmd.AddCommand(promptsPublishCmd)
+}
+
+func runPromptsPublish(cmd *cobra.Command, args []string) error {
+	_, promptsPath, err := findPromptsPath()
+	if err != nil {
+		return err
+	}
+
+	templatePath, err := prompts.Find(promptsPath, args[0])
+	if err != nil {
+		return err
+	}
+	filename := filepath.Base(templatePath)
+
+	local, err := os.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+
+	fmt.Println(color.CyanString("Fetching upstream version of %s...", filename))
+	upstream, err := github.FetchUpstreamPrompt(filename)
+	if err != nil {
+		return err
+	}
+
+	changes := diff.Unified(string(upstream.Content), string(local), "upstream/"+upstream.Path, "local/"+filename)
+	if changes == "" {
+		color.Green("✓ %s matches upstream. Nothing to publish.", filename)
+		return nil
+	}
+
+	fmt.Println()
+	if upstream.Exists {
+		color.Cyan("Changes against upstream:")
+	} else {
+		color.Cyan("%s does not exist upstream yet. It will be added:", filename)
+	}
+	fmt.Println(changes)
+
+	if publishDryRun {
+		return nil
+	}
+
+	if !publishYes {
+		confirm := promptui.Prompt{
+			Label:     "Open a pull request with this change",
+			IsConfirm: true,
+		}
+		if _, err := confirm.Run(); err != nil {
+			color.Yellow("Publish cancelled.")
+			return nil
+		}
+	}
+
+	name := strings.TrimSuffix(filename, filepath.Ext(filename))
+	title := publishTitle
+	if title == "" {
+		verb := "Update"
+		if !upstream.Exists {
+			verb = "Add"
+		}
+		title = fmt.Sprintf("%s %s prompt", verb, prompts.DisplayName(filename))
+	}
+
+	branch := publishBranch
+	if branch == "" {
+		branch = fmt.Sprintf("now-sc/%s-%s", strings.ToLower(strings.ReplaceAll(name, "_", "-")), time.Now().Format("20060102-150405"))
+	}
+
+	fmt.Println(color.CyanString("Opening pull request..."))
+	pr, err := github.PublishPrompt(github.PublishRequest{
+		Filename: filename,
+		Content:  local,
+		Upstream: upstream,
+		Title:    title,
+		Body:     publishDescription(filename, upstream, string(local), changes),
+		Branch:   branch,
+	})
+	if err != nil {
+		return err
+	}
+
+	color.Green("✓ Pull request #%d opened: %s", pr.Number, pr.HTMLURL)
+	return nil
+}
+
+// publishDescription generates the pull request body for a prompt change
+func publishDescription(filename string, upstream *github.UpstreamFile, local, changes string) string {
+	added, removed := diff.Stats(string(upstream.Content), local)
+
+	var b strings.Builder
+	if upstream.Exists {
+		fmt.Fprintf(&b, "Updates `%s` with improvements made in a customer project.\n\n", upstream.Path)
+	} else {
+		fmt.Fprintf(&b, "Adds `%s/%s`, a new prompt created in a customer project.\n\n", github.BasePromptsDir, filename)
+	}
+
+	if publishMessage != "" {
+		fmt.Fprintf(&b, "%s\n\n", publishMessage)
+	}
+
+	fmt.Fprintf(&b, "**Lines added:** %d  \n**Lines removed:** %d\n\n", added, removed)
+	fmt.Fprintf(&b, "<details>\n<summary>Diff</summary>\n\n```diff\n%s```\n\n</details>\n\n", changes)
+	b.WriteString("_Published with `now-sc prompts publish`._\n")
+	return b.String()
+}
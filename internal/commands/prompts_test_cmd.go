@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompttest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testProvider     string
+	testModel        string
+	testJudgeModel   string
+	testUpdateGolden bool
+	testStrictGolden bool
+)
+
+var promptsTestCmd = &cobra.Command{
+	Use:   "test [template...]",
+	Short: "Run regression fixtures against prompt templates",
+	Long: `Runs the fixtures stored beside each template (e.g. 10_PromptTemplates/Discovery_Prep.tests/*.yaml)
+through a provider, checks their assertions and compares the output with stored golden files.
+
+A fixture looks like:
+
+  name: basic
+  input: |
+    Notes from the first discovery call...
+  assert:
+    - contains: "Next steps"
+    - regex: "(?i)timeline"
+    - max_length: 4000
+    - json_schema: {type: object, required: [summary]}
+    - judge: "Summarises the customer's pain points without inventing facts"
+
+Golden outputs live beside the fixture as <fixture>.golden.md.`,
+	SilenceUsage: true,
+	RunE:         runPromptsTest,
+}
+
+func init() {
+	promptsTestCmd.Flags().StringVar(&testProvider, "provider", provider.OpenRouter, "Provider to run fixtures with (openrouter, fake)")
+	promptsTestCmd.Flags().StringVar(&testModel, "model", "", "Model to run fixtures with")
+	promptsTestCmd.Flags().StringVar(&testJudgeModel, "judge-model", "", "Model used for judge assertions (defaults to --model)")
+	promptsTestCmd.Flags().BoolVar(&testUpdateGolden, "update-golden", false, "Store outputs as the new golden files")
+	promptsTestCmd.Flags().BoolVar(&testStrictGolden, "strict-golden", false, "Fail fixtures whose output differs from the golden file")
+
+	promptsCmd.AddCommand(promptsTestCmd)
+}
+
+func runPromptsTest(cmd *cobra.Command, args []string) error {
+	_, promptsPath, err := findPromptsPath()
+	if err != nil {
+		return err
+	}
+
+	var templatePaths []string
+	if len(args) == 0 {
+		files, err := prompts.List(promptsPath)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			templatePaths = append(templatePaths, filepath.Join(promptsPath, file))
+		}
+	} else {
+		for _, ref := range args {
+			path, err := prompts.Find(promptsPath, ref)
+			if err != nil {
+				return err
+			}
+			templatePaths = append(templatePaths, path)
+		}
+	}
+
+	p, err := provider.New(testProvider, testModel)
+	if err != nil {
+		return err
+	}
+
+	runner := &prompttest.Runner{Provider: p, UpdateGolden: testUpdateGolden}
+	if testJudgeModel != "" {
+		if runner.Judge, err = provider.New(testProvider, testJudgeModel); err != nil {
+			return err
+		}
+	}
+
+	renderer := prompts.NewRenderer(promptsPath)
+	passed, failed := 0, 0
+
+	for _, templatePath := range templatePaths {
+		fixtures, err := prompttest.LoadFixtures(templatePath)
+		if err != nil {
+			return err
+		}
+		if len(fixtures) == 0 {
+			continue
+		}
+
+		promptContent, err := renderer.RenderFile(templatePath)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", filepath.Base(templatePath), err)
+		}
+
+		fmt.Println()
+		color.Cyan("%s (%s/%s)", filepath.Base(templatePath), p.Name(), p.Model())
+
+		for _, fixture := range fixtures {
+			result := runner.Run(promptContent, fixture)
+			ok := result.Passed() && (!testStrictGolden || result.MatchesGolden())
+			if ok {
+				passed++
+				color.Green("  ✓ %s", fixture.Name)
+			} else {
+				failed++
+				color.Red("  ✗ %s", fixture.Name)
+			}
+
+			if result.Err != nil {
+				fmt.Printf("      error: %v\n", result.Err)
+			}
+			for _, a := range result.Assertions {
+				switch {
+				case !a.Passed:
+					fmt.Printf("      ✗ %s: %s\n", a.Assertion.Describe(), a.Message)
+				case a.Message != "":
+					fmt.Printf("      ✓ %s (%s)\n", a.Assertion.Describe(), a.Message)
+				}
+			}
+
+			switch {
+			case result.GoldenUpdated:
+				fmt.Printf("      golden updated: %s\n", fixture.GoldenPath())
+			case result.GoldenDiff != "":
+				color.Yellow("      output differs from golden:")
+				for _, line := range strings.Split(strings.TrimRight(result.GoldenDiff, "\n"), "\n") {
+					fmt.Printf("        %s\n", line)
+				}
+			}
+		}
+	}
+
+	fmt.Println()
+	if passed+failed == 0 {
+		color.Yellow("No fixtures found. Add fixtures under <template>%s/ beside each template.", prompttest.FixtureDirSuffix)
+		return nil
+	}
+
+	summary := fmt.Sprintf("%d passed, %d failed", passed, failed)
+	if failed > 0 {
+		color.Red(summary)
+		return fmt.Errorf("%d prompt fixture(s) failed", failed)
+	}
+	color.Green(summary)
+	return nil
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/history"
+	"github.com/Now-AI-Foundry/Now-SC/internal/regenerate"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	regenerateProvider string
+	regenerateModel    string
+	regenerateInputs   []string
+	regenerateUpdated  bool
+)
+
+var regenerateCmd = &cobra.Command{
+	Use:   "regenerate <output.md>",
+	Short: "Re-run the template, inputs and parameters an output was generated with",
+	Long: `Reads the provenance front matter of a saved output and re-runs the same
+template, inputs and parameters with the same provider and model. The result is
+written alongside as the next version (Summary_v2.md, Summary_v3.md, ...) and its
+difference from the output is shown.
+
+The template and inputs must be unchanged since the output was generated, so the
+run is exact; pass --updated to use their current versions instead. --model and
+--provider switch models, and --input replaces the recorded input files.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRegenerate,
+}
+
+func init() {
+	regenerateCmd.Flags().StringVar(&regenerateProvider, "provider", "", "Provider to use instead of the recorded one (openrouter, fake)")
+	regenerateCmd.Flags().StringVar(&regenerateModel, "model", "", "Model to use instead of the recorded one")
+	regenerateCmd.Flags().StringArrayVar(&regenerateInputs, "input", nil, "Input file to use instead of the recorded ones (repeatable)")
+	regenerateCmd.Flags().BoolVar(&regenerateUpdated, "updated", false, "Use the template and inputs as they are now if they changed")
+}
+
+func runRegenerate(cmd *cobra.Command, args []string) error {
+	manifest, err := loadProject()
+	if err != nil {
+		return err
+	}
+	file, err := projectRelative(manifest, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(color.CyanString("Regenerating %s...", args[0]))
+	result, err := regenerate.Regenerate(manifest, file, regenerate.Options{
+		Provider: regenerateProvider,
+		Model:    regenerateModel,
+		Inputs:   regenerateInputs,
+		Updated:  regenerateUpdated,
+	})
+	var changed *regenerate.ChangedError
+	if errors.As(err, &changed) {
+		return fmt.Errorf("%w; pass --updated to regenerate with the current versions", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, path := range result.Changed {
+		color.Yellow("  %s changed since the output was generated; used the current version", path)
+	}
+	color.Green("✓ Wrote %s with %s", result.Output, result.Provenance.Model)
+	if err := history.Record(manifest, history.CommandRegenerate, manifest.Path(filepath.FromSlash(result.Output)), result.Provenance); err != nil {
+		color.Yellow("Warning: could not record the run in the history: %v", err)
+	}
+	if result.Provenance.Usage != nil {
+		fmt.Printf("  %d tokens\n", result.Provenance.Usage.TotalTokens)
+	}
+	if result.Diff == "" {
+		fmt.Println("The output did not change.")
+		return nil
+	}
+	fmt.Println()
+	printDiff(result.Diff)
+	return nil
+}
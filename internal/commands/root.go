@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/Now-AI-Foundry/Now-SC/internal/version"
 	"github.com/spf13/cobra"
 )
 
@@ -9,7 +10,7 @@ var rootCmd = &cobra.Command{
 	Short: "CLI tool for bootstrapping presales projects for solution consultants",
 	Long: `Now-SC is a CLI tool that helps solution consultants bootstrap and manage
 presales projects with structured directories, prompt templates, and AI-powered workflows.`,
-	Version: "1.0.0",
+	Version: version.Version,
 }
 
 // Execute runs the root command
@@ -18,7 +19,28 @@ func Execute() error {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&projectRef, "project", "", "Run against a workspace project by name or path instead of the current directory")
+
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(actionsCmd)
+	rootCmd.AddCommand(customerCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(emailCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(extractCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(ingestCmd)
+	rootCmd.AddCommand(layoutCmd)
+	rootCmd.AddCommand(manifestCmd)
+	rootCmd.AddCommand(overviewCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(profilesCmd)
+	rootCmd.AddCommand(projectsCmd)
 	rootCmd.AddCommand(promptCmd)
+	rootCmd.AddCommand(promptsCmd)
+	rootCmd.AddCommand(regenerateCmd)
+	rootCmd.AddCommand(transcriptCmd)
+	rootCmd.AddCommand(watchCmd)
 }
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/transcript"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	transcriptOutput string
+	transcriptStdout bool
+)
+
+var transcriptCmd = &cobra.Command{
+	Use:   "transcript <file...>",
+	Short: "Convert meeting transcripts to clean Markdown",
+	Long: `Parses WebVTT, SRT, Teams (.docx or .vtt) and Zoom text transcripts into speaker
+turns, merges consecutive turns of the same speaker, drops cue numbers and
+timestamps except one per turn, and writes <name>.md, or <name>_2.md and so
+on when that file exists.
+
+Transcripts already in the calls folder are converted in place; others are
+written to the inbox calls folder. "now-sc ingest" does this automatically for
+the transcripts it files.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runTranscript,
+}
+
+func init() {
+	transcriptCmd.Flags().StringVarP(&transcriptOutput, "output", "o", "", "Folder to write the Markdown to")
+	transcriptCmd.Flags().BoolVar(&transcriptStdout, "stdout", false, "Print the Markdown instead of writing it")
+}
+
+func runTranscript(cmd *cobra.Command, args []string) error {
+	var callsDir string
+	if !transcriptStdout && transcriptOutput == "" {
+		manifest, layout, err := loadProjectLayout()
+		if err != nil {
+			return err
+		}
+		if callsDir, err = filepath.Abs(manifest.Path(ingest.Folder(layout, ingest.KindTranscript, ""))); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range args {
+		t, err := transcript.ParseFile(file)
+		if err != nil {
+			return err
+		}
+		if transcriptStdout {
+			fmt.Print(string(t.Markdown()))
+			continue
+		}
+
+		dir := transcriptOutput
+		if dir == "" {
+			dir = callsDir
+			if abs, err := filepath.Abs(file); err == nil && strings.HasPrefix(abs, callsDir+string(filepath.Separator)) {
+				dir = filepath.Dir(abs)
+			}
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+
+		name := transcript.MarkdownName(file)
+		absFile, _ := filepath.Abs(file)
+		if absDest, _ := filepath.Abs(filepath.Join(dir, name)); absDest == absFile {
+			return fmt.Errorf("%s is already Markdown", file)
+		}
+		// Earlier conversions and notes of the same name are kept
+		stem := strings.TrimSuffix(name, ".md")
+		for i := 2; project.FileExists(dir, name); i++ {
+			name = fmt.Sprintf("%s_%d.md", stem, i)
+		}
+		dest := filepath.Join(dir, name)
+		if err := os.WriteFile(dest, t.Markdown(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", dest, err)
+		}
+		color.Green("✓ %s (%s, %d turns, %d speakers) → %s", file, t.Format, len(t.Turns), len(t.Speakers()), relPath(dest))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/Now-AI-Foundry/Now-SC/internal/watch"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	watchOnce     bool
+	watchYAML     bool
+	watchProvider string
+	watchModel    string
+	watchDebounce time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Process files dropped into the inbox as they arrive",
+	Long: `Watches the inbox and processes new and changed files once they have been left
+alone for a few seconds. Files dropped at the top of the inbox are filed as
+"now-sc ingest" does, then every configured workflow whose patterns match runs
+its prompt on the file (or its Markdown version) and writes the result to the
+workflow's output folder.
+
+Workflows are configured in .now-sc/watch.yaml; without it, every note,
+transcript, email thread and document is summarized into the layout's summaries
+folder (99_Assets/Summaries by default). Print the effective configuration with --yaml as a starting point.
+
+Processed files are recorded by content hash in .now-sc/watch-state.json, so
+restarting the watcher only picks up what changed while it was stopped and
+retries files whose last run failed.`,
+	SilenceUsage: true,
+	RunE:         runWatch,
+}
+
+func init() {
+	watchCmd.Flags().BoolVar(&watchOnce, "once", false, "Process new and changed files, then exit")
+	watchCmd.Flags().BoolVar(&watchYAML, "yaml", false, "Print the effective watch configuration")
+	watchCmd.Flags().StringVar(&watchProvider, "provider", "", "Provider running the workflows (openrouter, fake); overrides the configuration")
+	watchCmd.Flags().StringVar(&watchModel, "model", "", "Model running the workflows; overrides the configuration")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", 0, "How long a file must be left alone before it is processed")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	manifest, layout, err := loadProjectLayout()
+	if err != nil {
+		return err
+	}
+	config, err := watch.LoadConfig(manifest, layout)
+	if err != nil {
+		return err
+	}
+	if watchProvider != "" {
+		config.Provider = watchProvider
+	}
+	if watchModel != "" {
+		config.Model = watchModel
+	}
+	if watchDebounce > 0 {
+		config.Debounce = watchDebounce
+	}
+
+	if watchYAML {
+		content, err := yaml.Marshal(config)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(content))
+		return nil
+	}
+
+	w := &watch.Watcher{
+		Manifest: manifest,
+		Layout:   layout,
+		Config:   config,
+		Logf: func(format string, args ...any) {
+			fmt.Printf("%s %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+		},
+	}
+	if len(config.Workflows) > 0 || config.LLM {
+		if w.Provider, err = provider.New(config.Provider, config.Model); err != nil {
+			return err
+		}
+	}
+
+	if watchOnce {
+		return w.Scan()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	color.Cyan("Watching %s (Ctrl+C to stop); state in %s", filepath.ToSlash(layout.InboxDir()),
+		filepath.ToSlash(filepath.Join(project.MetaDir, watch.StateFile)))
+	return w.Run(ctx)
+}
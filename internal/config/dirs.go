@@ -0,0 +1,36 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// OrgDirEnv points at a directory holding organisation-wide configuration
+const OrgDirEnv = "NOW_SC_ORG_DIR"
+
+// UserDir returns the per-user configuration directory, e.g.
+// ~/.config/now-sc on Linux
+func UserDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "now-sc")
+}
+
+// OrgDir returns the organisation configuration directory, if configured
+func OrgDir() string {
+	return os.Getenv(OrgDirEnv)
+}
+
+// OverrideDirs returns the configuration directories in precedence order,
+// most specific first
+func OverrideDirs() []string {
+	var dirs []string
+	for _, dir := range []string{UserDir(), OrgDir()} {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
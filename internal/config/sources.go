@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TemplateSourcesEnv lists extra template source directories, separated by
+// the OS path list separator
+const TemplateSourcesEnv = "NOW_SC_TEMPLATE_SOURCES"
+
+// TemplateSources returns the configured template source directories in
+// lookup order
+func TemplateSources() []string {
+	var sources []string
+	for _, dir := range filepath.SplitList(os.Getenv(TemplateSourcesEnv)) {
+		dir = strings.TrimSpace(dir)
+		if dir != "" {
+			sources = append(sources, dir)
+		}
+	}
+	return sources
+}
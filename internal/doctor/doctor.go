@@ -0,0 +1,309 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/github"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+)
+
+// Severity ranks a finding
+type Severity int
+
+const (
+	// OK means the check passed
+	OK Severity = iota
+	// Warning is worth a look but does not break the project
+	Warning
+	// Problem breaks the project or leaks data and should be fixed
+	Problem
+)
+
+// Check names, in the order they run
+const (
+	CheckStructure   = "structure"
+	CheckManifest    = "manifest"
+	CheckPrompts     = "prompts"
+	CheckEnvironment = "environment"
+	CheckGitignore   = "gitignore"
+	CheckSecrets     = "secrets"
+)
+
+// Finding is the outcome of a single check
+type Finding struct {
+	Check    string
+	Severity Severity
+	Message  string
+	// Fix repairs the finding; nil when it has to be fixed by hand
+	Fix func() error
+}
+
+// Report collects the findings of a doctor run
+type Report struct {
+	Findings []Finding
+}
+
+// Count returns the number of findings with the given severity
+func (r *Report) Count(severity Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Fixable returns the findings that can be repaired automatically
+func (r *Report) Fixable() []Finding {
+	var fixable []Finding
+	for _, f := range r.Findings {
+		if f.Severity != OK && f.Fix != nil {
+			fixable = append(fixable, f)
+		}
+	}
+	return fixable
+}
+
+func (r *Report) add(check string, severity Severity, fix func() error, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{
+		Check:    check,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Fix:      fix,
+	})
+}
+
+// ok records a passing check when it produced no other findings
+func (r *Report) ok(check, format string, args ...interface{}) {
+	for _, f := range r.Findings {
+		if f.Check == check {
+			return
+		}
+	}
+	r.add(check, OK, nil, format, args...)
+}
+
+// Run checks a project against its layout and manifest. Fixes that change the
+// manifest update it in memory; callers save it after applying them.
+func Run(manifest *project.Manifest, layout *project.Layout) *Report {
+	r := &Report{}
+	checkStructure(r, manifest, layout)
+	checkManifest(r, manifest, layout)
+	checkPrompts(r, manifest)
+	checkEnvironment(r, manifest)
+	checkGitignore(r, manifest)
+	checkSecrets(r, manifest)
+	return r
+}
+
+func checkStructure(r *Report, m *project.Manifest, layout *project.Layout) {
+	var customers []string
+	for _, c := range m.Customers {
+		customers = append(customers, c.Dir)
+	}
+
+	for _, dir := range project.MissingDirectories(m.Root(), layout, "") {
+		r.add(CheckStructure, Problem, mkdir(m.Path(dir)), "missing folder %s/", filepath.ToSlash(dir))
+	}
+	for _, c := range m.Customers {
+		dir := filepath.Join(layout.CustomersDir(), c.Dir)
+		if !isDir(m.Path(dir)) {
+			r.add(CheckStructure, Problem, mkdir(m.Path(dir)), "missing folder %s/ for customer %s", filepath.ToSlash(dir), c.Name)
+		}
+	}
+
+	expected := map[string]bool{}
+	for _, dir := range layout.Directories {
+		expected[dir.Name] = true
+	}
+	if entries, err := os.ReadDir(m.Root()); err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() && !expected[name] && !strings.HasPrefix(name, ".") && name != "node_modules" {
+				r.add(CheckStructure, Warning, nil, "%s/ is not part of the project layout (renamed or moved?)", name)
+			}
+		}
+	}
+
+	if customersDir := layout.CustomersDir(); customersDir != "" {
+		if entries, err := os.ReadDir(m.Path(customersDir)); err == nil {
+			for _, entry := range entries {
+				if entry.IsDir() && m.FindCustomer(entry.Name()) < 0 && !strings.HasPrefix(entry.Name(), ".") {
+					r.add(CheckStructure, Warning, nil, "customer folder %s/ is not registered; add it with \"now-sc customer add\"",
+						filepath.ToSlash(filepath.Join(customersDir, entry.Name())))
+				}
+			}
+		}
+	}
+
+	r.ok(CheckStructure, "%d folders match the %s layout", len(layout.Paths(customers...)), layoutName(layout))
+}
+
+func checkManifest(r *Report, m *project.Manifest, layout *project.Layout) {
+	switch {
+	case m.LayoutVersion > project.LayoutVersion:
+		r.add(CheckManifest, Problem, nil, "layout version %d is newer than this CLI supports (%d); upgrade now-sc",
+			m.LayoutVersion, project.LayoutVersion)
+	case m.LayoutVersion < project.LayoutVersion:
+		r.add(CheckManifest, Warning, nil, "layout version %d is older than the current version %d; run \"now-sc migrate\"",
+			m.LayoutVersion, project.LayoutVersion)
+	}
+
+	if m.Stage != "" && !contains(prompts.Stages, m.Stage) {
+		r.add(CheckManifest, Warning, nil, "unknown stage %q (expected one of %s)", m.Stage, strings.Join(prompts.Stages, ", "))
+	}
+	if len(m.Customers) == 0 {
+		r.add(CheckManifest, Warning, nil, "no customers registered; add one with \"now-sc customer add\"")
+	}
+
+	if _, err := os.Stat(m.Path(project.MetaDir, project.LayoutSnapshotFile)); os.IsNotExist(err) {
+		r.add(CheckManifest, Warning, func() error { return m.SaveLayoutSnapshot(layout) },
+			"no layout snapshot in %s/%s", project.MetaDir, project.LayoutSnapshotFile)
+	}
+
+	r.ok(CheckManifest, "manifest is valid (layout version %d)", m.LayoutVersion)
+}
+
+func checkPrompts(r *Report, m *project.Manifest) {
+	if len(m.Prompts) == 0 {
+		r.ok(CheckPrompts, "no base prompt versions recorded")
+		return
+	}
+
+	names := make([]string, 0, len(m.Prompts))
+	for name := range m.Prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := m.Path(prompts.TemplatesDir, name)
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			r.add(CheckPrompts, Problem, restorePrompt(m, name), "%s is missing", name)
+			continue
+		}
+		if err != nil {
+			r.add(CheckPrompts, Problem, nil, "cannot read %s: %v", name, err)
+			continue
+		}
+		if github.BlobSHA(content) != m.Prompts[name] {
+			r.add(CheckPrompts, Warning, nil, "%s differs from the installed version; share improvements with \"now-sc prompts publish\"", name)
+		}
+	}
+
+	r.ok(CheckPrompts, "%d base prompts match their recorded versions", len(names))
+}
+
+func checkEnvironment(r *Report, m *project.Manifest) {
+	dotEnv, _ := config.LoadDotEnv(m.Path(config.DotEnvFile))
+	lookup := func(key string) (string, string) {
+		if value := os.Getenv(key); value != "" {
+			return value, "environment"
+		}
+		if value := dotEnv[key]; value != "" {
+			return value, config.DotEnvFile
+		}
+		return "", ""
+	}
+
+	key, source := lookup("OPENROUTER_API_KEY")
+	switch {
+	case source == "":
+		r.add(CheckEnvironment, Problem, nil, "OPENROUTER_API_KEY is not set in the environment or %s", config.DotEnvFile)
+	case strings.HasPrefix(key, "your_"):
+		r.add(CheckEnvironment, Problem, nil, "OPENROUTER_API_KEY in %s is still the placeholder value", source)
+	}
+
+	if _, source := lookup("GITHUB_PAT"); source == "" {
+		r.add(CheckEnvironment, Warning, nil, "GITHUB_PAT is not set; repository creation and prompt publishing are unavailable")
+	}
+
+	r.ok(CheckEnvironment, "required keys are set")
+}
+
+func checkGitignore(r *Report, m *project.Manifest) {
+	path := m.Path(".gitignore")
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		r.add(CheckGitignore, Problem, nil, "cannot read .gitignore: %v", err)
+		return
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		switch strings.TrimSpace(line) {
+		case ".env", "/.env", ".env*", "*.env":
+			r.ok(CheckGitignore, ".gitignore excludes %s", config.DotEnvFile)
+			return
+		}
+	}
+
+	message := ".gitignore does not exclude .env"
+	if os.IsNotExist(err) {
+		message = "no .gitignore; .env would be committed"
+	}
+	r.add(CheckGitignore, Problem, func() error { return appendLine(path, config.DotEnvFile) }, "%s", message)
+}
+
+func restorePrompt(m *project.Manifest, name string) func() error {
+	return func() error {
+		upstream, err := github.FetchUpstreamPrompt(name)
+		if err != nil {
+			return err
+		}
+		if !upstream.Exists {
+			return fmt.Errorf("%s no longer exists upstream", name)
+		}
+		if err := os.WriteFile(m.Path(prompts.TemplatesDir, name), upstream.Content, 0644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+		m.Prompts[name] = upstream.SHA
+		return nil
+	}
+}
+
+func mkdir(path string) func() error {
+	return func() error {
+		return os.MkdirAll(path, 0755)
+	}
+}
+
+func appendLine(path, line string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	content = append(content, line+"\n"...)
+	return os.WriteFile(path, content, 0644)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func layoutName(layout *project.Layout) string {
+	if layout.Source == "" {
+		return project.BuiltinSource
+	}
+	return filepath.Base(layout.Source)
+}
@@ -0,0 +1,99 @@
+package doctor
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// maxScanSize skips large files, which are assets rather than config or notes
+const maxScanSize = 1 << 20
+
+var secretPatterns = []struct {
+	Name    string
+	Pattern *regexp.Regexp
+}{
+	{"OpenRouter API key", regexp.MustCompile(`sk-or-(?:v1-)?[A-Za-z0-9]{32,}`)},
+	{"GitHub token", regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36}`)},
+	{"GitHub fine-grained token", regexp.MustCompile(`github_pat_[A-Za-z0-9_]{22,}`)},
+	{"AWS access key", regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+}
+
+func checkSecrets(r *Report, m *project.Manifest) {
+	files, tracked := trackedFiles(m.Root())
+	scope := "tracked files"
+	if !tracked {
+		scope = "project files"
+	}
+
+	for _, file := range files {
+		if tracked && filepath.Base(file) == config.DotEnvFile {
+			r.add(CheckSecrets, Problem, nil, "%s is tracked by git; remove it with \"git rm --cached %s\"", file, file)
+			continue
+		}
+
+		path := filepath.Join(m.Root(), filepath.FromSlash(file))
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxScanSize {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(content, 0) >= 0 {
+			continue
+		}
+
+		for i, line := range strings.Split(string(content), "\n") {
+			for _, secret := range secretPatterns {
+				if secret.Pattern.MatchString(line) {
+					r.add(CheckSecrets, Problem, nil, "possible %s in %s:%d", secret.Name, file, i+1)
+				}
+			}
+		}
+	}
+
+	r.ok(CheckSecrets, "no secrets found in %d %s", len(files), scope)
+}
+
+// trackedFiles lists the files git tracks in root. Outside a git repository it
+// walks the project instead, skipping .env, which is meant to hold secrets.
+func trackedFiles(root string) ([]string, bool) {
+	out, err := exec.Command("git", "-C", root, "ls-files", "-z").Output()
+	if err == nil {
+		var files []string
+		for _, file := range strings.Split(string(out), "\x00") {
+			if file != "" {
+				files = append(files, file)
+			}
+		}
+		return files, true
+	}
+
+	var files []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (name == ".git" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == config.DotEnvFile {
+			return nil
+		}
+		if rel, err := filepath.Rel(root, path); err == nil {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return files, false
+}
@@ -0,0 +1,340 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Extensions lists the file extensions of mail files
+var Extensions = []string{".eml", ".mbox"}
+
+// Message is a parsed email
+type Message struct {
+	ID         string
+	InReplyTo  string
+	References []string
+	From       string
+	To         []string
+	Cc         []string
+	Subject    string
+	Date       time.Time
+	// Text is the plain text body, converted from HTML when there is no
+	// plain text part
+	Text        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+var (
+	idPattern   = regexp.MustCompile(`<[^<>\s]+>`)
+	wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+)
+
+// IsMailFile reports whether a file name is a mail file
+func IsMailFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFile reads a .eml or .mbox file
+func ParseFile(path string) ([]*Message, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".mbox") {
+		return ParseMbox(f)
+	}
+	msg, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return []*Message{msg}, nil
+}
+
+// ParseMbox splits an mbox file into messages. Each message starts with a
+// "From " line; ">From " escapes in bodies are undone.
+func ParseMbox(r io.Reader) ([]*Message, error) {
+	var messages []*Message
+	var current bytes.Buffer
+	flush := func() error {
+		if current.Len() == 0 {
+			return nil
+		}
+		msg, err := Parse(bytes.NewReader(current.Bytes()))
+		current.Reset()
+		if err != nil {
+			return fmt.Errorf("message %d: %w", len(messages)+1, err)
+		}
+		messages = append(messages, msg)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = line[1:]
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// Parse reads a single RFC 5322 message
+func Parse(r io.Reader) (*Message, error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		ID:         firstID(m.Header.Get("Message-ID")),
+		InReplyTo:  firstID(m.Header.Get("In-Reply-To")),
+		References: idPattern.FindAllString(m.Header.Get("References"), -1),
+		From:       decodeAddress(m.Header.Get("From")),
+		To:         decodeAddresses(m.Header.Get("To")),
+		Cc:         decodeAddresses(m.Header.Get("Cc")),
+		Subject:    decodeHeader(m.Header.Get("Subject")),
+	}
+	if date, err := m.Header.Date(); err == nil {
+		msg.Date = date
+	}
+
+	var text, html string
+	err = walkPart(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), "", m.Body,
+		func(contentType, filename string, data []byte) {
+			switch {
+			case filename != "":
+				msg.Attachments = append(msg.Attachments, Attachment{Filename: filename, ContentType: contentType, Data: data})
+			case contentType == "text/plain" && text == "":
+				text = string(data)
+			case contentType == "text/html" && html == "":
+				html = string(data)
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if text == "" && html != "" {
+		text = HTMLToText(html)
+	}
+	msg.Text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	return msg, nil
+}
+
+// walkPart decodes a MIME part, descending into multiparts, and calls fn with
+// each leaf part's content type, attachment file name and decoded content
+func walkPart(contentType, encoding, disposition string, body io.Reader, fn func(contentType, filename string, data []byte)) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("invalid multipart message: %w", err)
+			}
+			err = walkPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"),
+				part.Header.Get("Content-Disposition"), part, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(encoding, body))
+	if err != nil {
+		return fmt.Errorf("failed to decode %s part: %w", mediaType, err)
+	}
+
+	filename := params["name"]
+	if d, dparams, err := mime.ParseMediaType(disposition); err == nil {
+		if dparams["filename"] != "" {
+			filename = dparams["filename"]
+		}
+		if d == "attachment" && filename == "" {
+			filename = "attachment"
+		}
+	}
+	if mediaType == "message/rfc822" && filename == "" {
+		filename = "forwarded.eml"
+	}
+	if filename != "" {
+		filename = safeFilename(decodeHeader(filename))
+	}
+
+	if filename == "" && strings.HasPrefix(mediaType, "text/") {
+		data = toUTF8(data, params["charset"])
+	}
+	fn(mediaType, filename, data)
+	return nil
+}
+
+// safeFilename keeps the last element of an attachment's file name, whatever
+// the sender's path separator, and replaces names that are not a file
+func safeFilename(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &whitespaceStripper{r: r})
+	}
+	return r
+}
+
+// whitespaceStripper drops the line breaks in base64 bodies
+type whitespaceStripper struct {
+	r io.Reader
+}
+
+func (w *whitespaceStripper) Read(p []byte) (int, error) {
+	for {
+		n, err := w.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+				p[kept] = b
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+func decodeAddress(value string) string {
+	addrs := decodeAddresses(value)
+	if len(addrs) == 0 {
+		return decodeHeader(value)
+	}
+	return addrs[0]
+}
+
+func decodeAddresses(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	parser := mail.AddressParser{WordDecoder: wordDecoder}
+	list, err := parser.ParseList(value)
+	if err != nil {
+		return []string{decodeHeader(value)}
+	}
+	addrs := make([]string, len(list))
+	for i, a := range list {
+		if a.Name != "" {
+			addrs[i] = fmt.Sprintf("%s <%s>", a.Name, a.Address)
+		} else {
+			addrs[i] = a.Address
+		}
+	}
+	return addrs
+}
+
+func firstID(value string) string {
+	if id := idPattern.FindString(value); id != "" {
+		return id
+	}
+	return strings.TrimSpace(value)
+}
+
+// charsetReader decodes the single-byte charsets common in mail; UTF-8 and
+// ASCII pass through
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(toUTF8(data, charset)), nil
+}
+
+// toUTF8 converts Latin-1 and Windows-1252 text to UTF-8; other charsets are
+// returned unchanged
+func toUTF8(data []byte, charset string) []byte {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "iso-8859-15", "windows-1252", "cp1252":
+		var b strings.Builder
+		for _, c := range data {
+			if r, ok := cp1252[c]; ok {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune(rune(c))
+			}
+		}
+		return []byte(b.String())
+	}
+	return data
+}
+
+// cp1252 maps the Windows-1252 bytes that differ from Latin-1
+var cp1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x89: '‰', 0x8B: '‹', 0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”',
+	0x95: '•', 0x96: '–', 0x97: '—', 0x99: '™', 0x9B: '›',
+}
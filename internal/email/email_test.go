@@ -0,0 +1,239 @@
+package email
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// crlf converts a message written with \n line endings to the wire format
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+func TestParseHeaders(t *testing.T) {
+	msg, err := Parse(strings.NewReader(crlf(`Message-ID: <b@example.com>
+In-Reply-To: <a@example.com>
+References: <root@example.com> <a@example.com>
+From: =?UTF-8?Q?J=C3=BCrgen_M=C3=BCller?= <jurgen@example.com>
+To: Jane Doe <jane@example.com>, bob@example.com
+Cc: "Smith, Ann" <ann@example.com>
+Subject: =?ISO-8859-1?Q?Re:_Pr=E4sentation?=
+Date: Tue, 14 May 2024 10:30:00 +0200
+
+Hello
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.ID != "<b@example.com>" || msg.InReplyTo != "<a@example.com>" {
+		t.Errorf("IDs: got %q, %q", msg.ID, msg.InReplyTo)
+	}
+	if strings.Join(msg.References, " ") != "<root@example.com> <a@example.com>" {
+		t.Errorf("References: got %v", msg.References)
+	}
+	if msg.From != "Jürgen Müller <jurgen@example.com>" {
+		t.Errorf("From: got %q", msg.From)
+	}
+	if strings.Join(msg.To, "; ") != "Jane Doe <jane@example.com>; bob@example.com" {
+		t.Errorf("To: got %v", msg.To)
+	}
+	if strings.Join(msg.Cc, "; ") != "Smith, Ann <ann@example.com>" {
+		t.Errorf("Cc: got %v", msg.Cc)
+	}
+	if msg.Subject != "Re: Präsentation" {
+		t.Errorf("Subject: got %q", msg.Subject)
+	}
+	if want := time.Date(2024, 5, 14, 8, 30, 0, 0, time.UTC); !msg.Date.Equal(want) {
+		t.Errorf("Date: got %v, want %v", msg.Date, want)
+	}
+	if msg.Text != "Hello" {
+		t.Errorf("Text: got %q", msg.Text)
+	}
+}
+
+func TestParseBodies(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name: "quoted-printable latin-1",
+			message: `Content-Type: text/plain; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+Gr=FC=DFe, the price is 10=80 and this line is so long that it is =
+wrapped.
+`,
+			want: "Grüße, the price is 10€ and this line is so long that it is wrapped.",
+		},
+		{
+			name: "base64",
+			message: `Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: base64
+
+SGVsbG8g
+V29ybGQ=
+`,
+			want: "Hello World",
+		},
+		{
+			name: "plain text preferred over HTML",
+			message: `Content-Type: multipart/alternative; boundary="b1"
+
+--b1
+Content-Type: text/plain
+
+Plain body
+--b1
+Content-Type: text/html
+
+<p>HTML body</p>
+--b1--
+`,
+			want: "Plain body",
+		},
+		{
+			name: "HTML only",
+			message: `Content-Type: text/html
+
+<html><head><title>x</title></head><body><p>First</p><ul><li>One</li><li>Two</li></ul></body></html>
+`,
+			want: "First\n\n- One\n- Two",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := Parse(strings.NewReader(crlf("Subject: test\n" + tt.message)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg.Text != tt.want {
+				t.Errorf("got %q, want %q", msg.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAttachments(t *testing.T) {
+	msg, err := Parse(strings.NewReader(crlf(`Subject: Files
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain
+
+See attached.
+--inner--
+--outer
+Content-Type: application/pdf; name="quote.pdf"
+Content-Disposition: attachment; filename="quote.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0=
+--outer
+Content-Type: text/plain
+Content-Disposition: attachment; filename="../../etc/passwd"
+
+root
+--outer
+Content-Type: application/octet-stream
+Content-Disposition: attachment; filename="C:\Users\jane\notes.txt"
+
+notes
+--outer
+Content-Type: application/octet-stream
+Content-Disposition: attachment; filename=".."
+
+dots
+--outer
+Content-Type: application/octet-stream
+Content-Disposition: attachment; filename="."
+
+dot
+--outer
+Content-Type: message/rfc822
+
+Subject: Forwarded
+
+Inner
+--outer--
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Text != "See attached." {
+		t.Errorf("Text: got %q", msg.Text)
+	}
+	want := []struct{ name, data string }{
+		{"quote.pdf", "%PDF-"},
+		{"passwd", "root"},
+		{"notes.txt", "notes"},
+		{"attachment", "dots"},
+		{"attachment", "dot"},
+		{"forwarded.eml", "Subject: Forwarded\r\n\r\nInner"},
+	}
+	if len(msg.Attachments) != len(want) {
+		t.Fatalf("got %d attachments, want %d: %+v", len(msg.Attachments), len(want), msg.Attachments)
+	}
+	for i, w := range want {
+		a := msg.Attachments[i]
+		if a.Filename != w.name || string(a.Data) != w.data {
+			t.Errorf("attachment %d: got %q with %q, want %q with %q", i, a.Filename, a.Data, w.name, w.data)
+		}
+	}
+}
+
+func TestParseMbox(t *testing.T) {
+	mbox := `From jane@example.com Tue May 14 10:30:00 2024
+Subject: First
+Message-ID: <1@example.com>
+
+Body one
+>From the start, this line was escaped
+
+From bob@example.com Tue May 14 11:00:00 2024
+Subject: Re: First
+Message-ID: <2@example.com>
+In-Reply-To: <1@example.com>
+
+Body two
+`
+	messages, err := ParseMbox(strings.NewReader(mbox))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	if want := "Body one\nFrom the start, this line was escaped"; messages[0].Text != want {
+		t.Errorf("got %q, want %q", messages[0].Text, want)
+	}
+	if messages[1].InReplyTo != "<1@example.com>" || messages[1].Text != "Body two" {
+		t.Errorf("second message: %+v", messages[1])
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":           "report.pdf",
+		"../report.pdf":        "report.pdf",
+		`..\..\report.pdf`:     "report.pdf",
+		"/etc/":                "attachment",
+		"..":                   "attachment",
+		".":                    "attachment",
+		"  ":                   "attachment",
+		"a/b/.hidden":          ".hidden",
+		"Q3 forecast (1).xlsx": "Q3 forecast (1).xlsx",
+	}
+	for in, want := range tests {
+		if got := safeFilename(in); got != want {
+			t.Errorf("safeFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package email
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	dropBlocks   = regexp.MustCompile(`(?is)<(script|style|head|title)\b.*?</(script|style|head|title)>`)
+	htmlComments = regexp.MustCompile(`(?s)<!--.*?-->`)
+	lineBreaks   = regexp.MustCompile(`(?i)<br\s*/?>`)
+	blockEnds    = regexp.MustCompile(`(?i)</(p|div|tr|table|h[1-6]|ul|ol|blockquote)>`)
+	listItems    = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	headings     = regexp.MustCompile(`(?i)<h([1-6])\b[^>]*>`)
+	cells        = regexp.MustCompile(`(?i)</t[dh]>`)
+	links        = regexp.MustCompile(`(?is)<a\b[^>]*href=["']([^"']+)["'][^>]*>(.*?)</a>`)
+	anyTag       = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaces       = regexp.MustCompile(`[ \t\x{00A0}]+`)
+	blankLines   = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText converts an HTML mail body to readable plain text, keeping
+// paragraphs, list items, headings and link targets
+func HTMLToText(s string) string {
+	s = dropBlocks.ReplaceAllString(s, "")
+	s = htmlComments.ReplaceAllString(s, "")
+	s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+	s = links.ReplaceAllStringFunc(s, func(m string) string {
+		parts := links.FindStringSubmatch(m)
+		text := strings.TrimSpace(anyTag.ReplaceAllString(parts[2], ""))
+		href := parts[1]
+		if text == "" || text == href || strings.HasPrefix(href, "mailto:") {
+			return text
+		}
+		return text + " (" + href + ")"
+	})
+	s = lineBreaks.ReplaceAllString(s, "\n")
+	s = headings.ReplaceAllStringFunc(s, func(m string) string {
+		// Nested below the thread and message headings
+		level := min(int(headings.FindStringSubmatch(m)[1][0]-'0')+2, 6)
+		return "\n\n" + strings.Repeat("#", level) + " "
+	})
+	s = blockEnds.ReplaceAllString(s, "\n\n")
+	s = listItems.ReplaceAllString(s, "\n- ")
+	s = cells.ReplaceAllString(s, " | ")
+	s = anyTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+	s = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n"))
+}
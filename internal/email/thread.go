@@ -0,0 +1,286 @@
+package email
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Thread is a conversation: a message and its replies, oldest first
+type Thread struct {
+	Subject  string
+	Messages []*Message
+}
+
+var (
+	replyPrefix = regexp.MustCompile(`(?i)^\s*((re|fw|fwd|aw|wg|sv|tr)(\[\d+\])?\s*:\s*)+`)
+	// "On Tue, 14 May 2024, Jane wrote:" and Outlook's "From: ... Sent: ..."
+	quoteHeader  = regexp.MustCompile(`(?im)^(on\s.{1,200}\swrote:|-{2,}\s*original message\s*-{2,}|from:\s.+\n\s*sent:\s.+)\s*$`)
+	unsafeInName = regexp.MustCompile(`[^\pL\pN._-]+`)
+)
+
+// Threads groups messages into conversations using Message-ID, In-Reply-To
+// and References. Replies whose parent is missing are grouped by subject.
+func Threads(messages []*Message) []*Thread {
+	parent := map[string]string{}
+	var find func(id string) string
+	find = func(id string) string {
+		for parent[id] != "" && parent[id] != id {
+			id = parent[id]
+		}
+		return id
+	}
+	union := func(a, b string) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[ra] = rb
+		}
+	}
+
+	keys := make([]string, len(messages))
+	bySubject := map[string]bool{}
+	for i, msg := range messages {
+		key := msg.ID
+		if key == "" {
+			key = fmt.Sprintf("#%d", i)
+		}
+		keys[i] = key
+		for _, ref := range append(append([]string{}, msg.References...), msg.InReplyTo) {
+			if ref != "" {
+				union(key, ref)
+			}
+		}
+		// Replies without references fall back to the subject
+		if len(msg.References) == 0 && msg.InReplyTo == "" && replyPrefix.MatchString(msg.Subject) {
+			subject := "subject:" + strings.ToLower(NormalizeSubject(msg.Subject))
+			union(key, subject)
+			bySubject[subject] = true
+		}
+	}
+	// Originals join the subject group their replies fell back to
+	for i, msg := range messages {
+		subject := "subject:" + strings.ToLower(NormalizeSubject(msg.Subject))
+		if bySubject[subject] && !replyPrefix.MatchString(msg.Subject) {
+			union(keys[i], subject)
+		}
+	}
+
+	groups := map[string]*Thread{}
+	var threads []*Thread
+	for i, msg := range messages {
+		root := find(keys[i])
+		t, ok := groups[root]
+		if !ok {
+			t = &Thread{}
+			groups[root] = t
+			threads = append(threads, t)
+		}
+		t.Messages = append(t.Messages, msg)
+	}
+
+	for _, t := range threads {
+		sort.SliceStable(t.Messages, func(i, j int) bool { return t.Messages[i].Date.Before(t.Messages[j].Date) })
+		t.Subject = NormalizeSubject(t.Messages[0].Subject)
+		if t.Subject == "" {
+			t.Subject = "(no subject)"
+		}
+	}
+	sort.SliceStable(threads, func(i, j int) bool { return threads[i].Started().Before(threads[j].Started()) })
+	return threads
+}
+
+// NormalizeSubject strips reply and forward prefixes
+func NormalizeSubject(subject string) string {
+	return strings.TrimSpace(replyPrefix.ReplaceAllString(subject, ""))
+}
+
+// Started returns the date of the first message
+func (t *Thread) Started() time.Time {
+	return t.Messages[0].Date
+}
+
+// Participants returns everyone who sent a message, in order of appearance
+func (t *Thread) Participants() []string {
+	seen := map[string]bool{}
+	var people []string
+	for _, msg := range t.Messages {
+		if msg.From != "" && !seen[msg.From] {
+			seen[msg.From] = true
+			people = append(people, msg.From)
+		}
+	}
+	return people
+}
+
+// Name returns the base file name for the thread, e.g.
+// 2024-05-14_Pricing_proposal
+func (t *Thread) Name() string {
+	name := strings.Trim(unsafeInName.ReplaceAllString(t.Subject, "_"), "_.")
+	if r := []rune(name); len(r) > 60 {
+		name = strings.TrimRight(string(r[:60]), "_.")
+	}
+	if name == "" {
+		name = "email"
+	}
+	if date := t.Started(); !date.IsZero() {
+		name = date.Format("2006-01-02") + "_" + name
+	}
+	return name
+}
+
+// AttachmentsSuffix ends the name of the folder next to a thread holding its
+// attachments
+const AttachmentsSuffix = "_attachments"
+
+// AttachmentsDir returns the name of the folder holding the thread's
+// attachments, next to its Markdown file
+func (t *Thread) AttachmentsDir() string {
+	return t.Name() + AttachmentsSuffix
+}
+
+// Markdown renders the thread with one section per message. Text quoted from
+// earlier messages is dropped since they appear in full above.
+func (t *Thread) Markdown() []byte {
+	return t.markdown(t.AttachmentsDir())
+}
+
+// markdown renders the thread, linking attachments into attachDir
+func (t *Thread) markdown(attachDir string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Subject)
+
+	details := []string{fmt.Sprintf("Messages: %d", len(t.Messages))}
+	if people := t.Participants(); len(people) > 0 {
+		details = append(details, "Participants: "+strings.Join(people, ", "))
+	}
+	b.WriteString(strings.Join(details, " · ") + "\n")
+
+	names := t.attachmentNames()
+	for i, msg := range t.Messages {
+		fmt.Fprintf(&b, "\n## %d. %s", i+1, orUnknown(msg.From))
+		if !msg.Date.IsZero() {
+			fmt.Fprintf(&b, " — %s", msg.Date.Format("2006-01-02 15:04 MST"))
+		}
+		b.WriteString("\n")
+		var headers strings.Builder
+		if len(msg.To) > 0 {
+			fmt.Fprintf(&headers, "**To:** %s  \n", strings.Join(msg.To, ", "))
+		}
+		if len(msg.Cc) > 0 {
+			fmt.Fprintf(&headers, "**Cc:** %s  \n", strings.Join(msg.Cc, ", "))
+		}
+		if msg.Subject != "" && NormalizeSubject(msg.Subject) != t.Subject {
+			fmt.Fprintf(&headers, "**Subject:** %s  \n", msg.Subject)
+		}
+		if len(msg.Attachments) > 0 {
+			links := make([]string, len(msg.Attachments))
+			for j, a := range msg.Attachments {
+				links[j] = fmt.Sprintf("[%s](%s/%s)", a.Filename, url.PathEscape(attachDir), url.PathEscape(names[i][j]))
+			}
+			fmt.Fprintf(&headers, "**Attachments:** %s  \n", strings.Join(links, ", "))
+		}
+		if headers.Len() > 0 {
+			b.WriteString("\n" + headers.String())
+		}
+
+		text := msg.Text
+		if i > 0 {
+			text = StripQuoted(text)
+		}
+		fmt.Fprintf(&b, "\n%s\n", text)
+	}
+	return []byte(b.String())
+}
+
+// StripQuoted removes quoted replies: "> " lines and everything after an
+// "On ... wrote:" or "Original Message" marker
+func StripQuoted(text string) string {
+	if loc := quoteHeader.FindStringIndex(text); loc != nil {
+		text = text[:loc[0]]
+	}
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), ">") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// Write saves the thread as <name>.md in dir and its attachments in a
+// sibling folder, returning the Markdown path. Existing files are kept and a
+// free name is used instead.
+func (t *Thread) Write(dir string) (string, error) {
+	base := t.Name()
+	for i := 2; exists(filepath.Join(dir, base+".md")); i++ {
+		base = fmt.Sprintf("%s_%d", t.Name(), i)
+	}
+	mdPath := filepath.Join(dir, base+".md")
+
+	files := map[string][]byte{}
+	names := t.attachmentNames()
+	for i, msg := range t.Messages {
+		for j, a := range msg.Attachments {
+			files[names[i][j]] = a.Data
+		}
+	}
+	if len(files) > 0 {
+		attachDir := filepath.Join(dir, base+AttachmentsSuffix)
+		if err := os.MkdirAll(attachDir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create %s: %w", attachDir, err)
+		}
+		for name, data := range files {
+			if err := os.WriteFile(filepath.Join(attachDir, name), data, 0644); err != nil {
+				return "", fmt.Errorf("failed to write attachment %s: %w", name, err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(mdPath, t.markdown(base+AttachmentsSuffix), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", mdPath, err)
+	}
+	return mdPath, nil
+}
+
+// attachmentNames returns the file name each attachment is saved as, by
+// message and attachment. Names used earlier in the thread get a number, e.g.
+// a second report.pdf becomes report_2.pdf.
+func (t *Thread) attachmentNames() [][]string {
+	used := map[string]bool{}
+	names := make([][]string, len(t.Messages))
+	for i, msg := range t.Messages {
+		names[i] = make([]string, len(msg.Attachments))
+		for j, a := range msg.Attachments {
+			ext := filepath.Ext(a.Filename)
+			stem := strings.TrimSuffix(a.Filename, ext)
+			name := a.Filename
+			// Names differing only in case collide on Windows and macOS
+			for n := 2; used[strings.ToLower(name)]; n++ {
+				name = fmt.Sprintf("%s_%d%s", stem, n, ext)
+			}
+			used[strings.ToLower(name)] = true
+			names[i][j] = name
+		}
+	}
+	return names
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "(unknown sender)"
+	}
+	return s
+}
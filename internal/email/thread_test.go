@@ -0,0 +1,139 @@
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(hour int) time.Time {
+	return time.Date(2024, 5, 14, hour, 0, 0, 0, time.UTC)
+}
+
+func TestThreads(t *testing.T) {
+	messages := []*Message{
+		{ID: "<3@x>", InReplyTo: "<2@x>", References: []string{"<1@x>", "<2@x>"}, Subject: "RE: Pricing", Date: day(12)},
+		{ID: "<1@x>", Subject: "Pricing", Date: day(10)},
+		{ID: "<4@x>", Subject: "Agenda", Date: day(9)},
+		{ID: "<2@x>", InReplyTo: "<1@x>", Subject: "Re: Pricing", Date: day(11)},
+		// Replies without references join by subject
+		{ID: "<6@x>", Subject: "Re: Agenda", Date: day(13)},
+		{ID: "<5@x>", Subject: "Fwd: Unrelated", Date: day(8)},
+	}
+	threads := Threads(messages)
+
+	var got []string
+	for _, th := range threads {
+		var ids []string
+		for _, msg := range th.Messages {
+			ids = append(ids, msg.ID)
+		}
+		got = append(got, th.Subject+": "+strings.Join(ids, " "))
+	}
+	want := []string{
+		"Unrelated: <5@x>",
+		"Agenda: <4@x> <6@x>",
+		"Pricing: <1@x> <2@x> <3@x>",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNormalizeSubject(t *testing.T) {
+	tests := map[string]string{
+		"Re: Pricing":          "Pricing",
+		"RE: Fwd: AW: Pricing": "Pricing",
+		"Re[2]: Pricing":       "Pricing",
+		"Regarding pricing":    "Regarding pricing",
+	}
+	for in, want := range tests {
+		if got := NormalizeSubject(in); got != want {
+			t.Errorf("NormalizeSubject(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestStripQuoted(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"quote marks", "Sounds good.\n> Earlier text\n>> Older", "Sounds good."},
+		{"wrote header", "Yes.\n\nOn Tue, 14 May 2024, Jane Doe wrote:\nEarlier text", "Yes."},
+		{"outlook header", "Agreed.\n\nFrom: Jane Doe\nSent: Tuesday, May 14, 2024\nEarlier text", "Agreed."},
+		{"original message", "Fine.\n-----Original Message-----\nEarlier", "Fine."},
+		{"nothing quoted", "Just text", "Just text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripQuoted(tt.text); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreadWriteKeepsDuplicateAttachments(t *testing.T) {
+	th := &Thread{Subject: "Pricing", Messages: []*Message{
+		{From: "jane@example.com", Date: day(10), Text: "First", Attachments: []Attachment{
+			{Filename: "quote.pdf", Data: []byte("v1")},
+			{Filename: "notes", Data: []byte("n1")},
+		}},
+		{From: "bob@example.com", Date: day(11), Text: "Second", Attachments: []Attachment{
+			{Filename: "quote.pdf", Data: []byte("v2")},
+			{Filename: "Quote.pdf", Data: []byte("v3")},
+			{Filename: "notes", Data: []byte("n2")},
+		}},
+	}}
+	dir := t.TempDir()
+	mdPath, err := th.Write(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(mdPath) != "2024-05-14_Pricing.md" {
+		t.Errorf("got %s", mdPath)
+	}
+
+	attachDir := filepath.Join(dir, "2024-05-14_Pricing"+AttachmentsSuffix)
+	files := map[string]string{
+		"quote.pdf":   "v1",
+		"notes":       "n1",
+		"quote_2.pdf": "v2",
+		"Quote_3.pdf": "v3",
+		"notes_2":     "n2",
+	}
+	for name, want := range files {
+		data, err := os.ReadFile(filepath.Join(attachDir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if string(data) != want {
+			t.Errorf("%s: got %q, want %q", name, data, want)
+		}
+	}
+
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, link := range []string{
+		"[quote.pdf](2024-05-14_Pricing_attachments/quote.pdf)",
+		"[quote.pdf](2024-05-14_Pricing_attachments/quote_2.pdf)",
+		"[Quote.pdf](2024-05-14_Pricing_attachments/Quote_3.pdf)",
+		"[notes](2024-05-14_Pricing_attachments/notes_2)",
+	} {
+		if !strings.Contains(string(md), link) {
+			t.Errorf("missing link %s in\n%s", link, md)
+		}
+	}
+
+	// Writing again keeps the first thread
+	second, err := th.Write(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(second) != "2024-05-14_Pricing_2.md" {
+		t.Errorf("got %s", second)
+	}
+}
@@ -0,0 +1,146 @@
+package extract
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const docxDocument = "word/document.xml"
+
+var headingStyle = regexp.MustCompile(`(?i)^heading\s?(\d)$`)
+
+// DocxParagraphs returns the plain text of each paragraph in a .docx
+// document, with line breaks as separate lines
+func DocxParagraphs(content []byte) ([]string, error) {
+	pkg, err := openOOXML(content)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := pkg.read(docxDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	var b strings.Builder
+	inText := false
+	err = walkXML(doc, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteString("\t")
+			case "br", "cr":
+				lines = append(lines, b.String())
+				b.Reset()
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				lines = append(lines, b.String())
+				b.Reset()
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	})
+	return lines, err
+}
+
+// docx converts a Word document to Markdown: heading styles become
+// headings, numbered and bulleted paragraphs list items, and tables
+// Markdown tables
+func docx(content []byte) (string, error) {
+	pkg, err := openOOXML(content)
+	if err != nil {
+		return "", err
+	}
+	doc, err := pkg.read(docxDocument)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	var para strings.Builder
+	var tbl tableBuilder
+	var style string
+	list, inText, depth := false, false, 0
+
+	err = walkXML(doc, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "tbl":
+				depth++
+			case "p":
+				para.Reset()
+				style, list = "", false
+			case "pStyle":
+				style = attr(t, "val")
+			case "numPr":
+				list = true
+			case "t":
+				inText = true
+			case "tab":
+				para.WriteString(" ")
+			case "br", "cr":
+				para.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text := strings.TrimSpace(para.String())
+				if depth > 0 {
+					tbl.addText(text)
+				} else if text != "" {
+					out.WriteString(docxParagraph(text, style, list))
+				}
+			case "tc":
+				if depth == 1 {
+					tbl.endCell()
+				}
+			case "tr":
+				if depth == 1 {
+					tbl.endRow()
+				}
+			case "tbl":
+				if depth--; depth == 0 {
+					out.WriteString("\n" + tbl.render() + "\n")
+				}
+			}
+		case xml.CharData:
+			if inText {
+				para.Write(t)
+			}
+		}
+	})
+	return out.String(), err
+}
+
+func docxParagraph(text, style string, list bool) string {
+	if m := headingStyle.FindStringSubmatch(style); m != nil {
+		level, _ := strconv.Atoi(m[1])
+		return "\n" + strings.Repeat("#", min(max(level, 1), 6)) + " " + oneLine(text) + "\n\n"
+	}
+	switch {
+	case strings.EqualFold(style, "Title"):
+		return "\n# " + oneLine(text) + "\n\n"
+	case list || strings.HasPrefix(strings.ToLower(style), "list"):
+		return "- " + oneLine(text) + "\n"
+	}
+	return text + "\n\n"
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,123 @@
+package extract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CacheSuffix is appended to a document's file name for its extracted
+// Markdown, e.g. RFP.pdf.md next to RFP.pdf
+const CacheSuffix = ".md"
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// extractors turn a document's content into Markdown, by extension
+var extractors = map[string]func(content []byte) (string, error){
+	".docx": docx,
+	".pptx": pptx,
+	".xlsx": xlsx,
+	".pdf":  pdf,
+}
+
+// Supported reports whether text can be extracted from a file
+func Supported(name string) bool {
+	_, ok := extractors[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
+
+// IsCache reports whether a file is the extracted Markdown of a document
+func IsCache(name string) bool {
+	source, ok := strings.CutSuffix(name, CacheSuffix)
+	return ok && Supported(source)
+}
+
+// CachePath returns where a document's extracted Markdown is cached
+func CachePath(path string) string {
+	return path + CacheSuffix
+}
+
+// Bytes extracts Markdown from a document's content, choosing the format
+// from its file name
+func Bytes(name string, content []byte) (string, error) {
+	fn, ok := extractors[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return "", fmt.Errorf("cannot extract text from %s: unsupported format", name)
+	}
+	text, err := fn(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract text from %s: %w", name, err)
+	}
+	text = strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+	if text == "" {
+		return "", fmt.Errorf("no text found in %s", name)
+	}
+	return text + "\n", nil
+}
+
+// File extracts Markdown from a document
+func File(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return Bytes(filepath.Base(path), content)
+}
+
+// Cached returns a document's Markdown from the cache next to it, extracting
+// it again when the document is newer than the cache
+func Cached(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	cache := CachePath(path)
+	if cached, err := os.Stat(cache); err == nil && !cached.ModTime().Before(info.ModTime()) {
+		content, err := os.ReadFile(cache)
+		if err == nil {
+			return string(content), nil
+		}
+	}
+
+	text, err := File(path)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(cache, []byte(text), 0644); err != nil {
+		return "", fmt.Errorf("failed to cache extracted text: %w", err)
+	}
+	return text, nil
+}
+
+// table renders rows as a Markdown table, the first row being the header
+func table(rows [][]string) string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, row := range rows {
+		cells := make([]string, width)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = escapeCell(row[j])
+			}
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat("---|", width) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func escapeCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,129 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ooxml is an Office Open XML package (.docx, .pptx, .xlsx)
+type ooxml struct {
+	files map[string]*zip.File
+}
+
+func openOOXML(content []byte) (*ooxml, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("not an Office document: %w", err)
+	}
+	pkg := &ooxml{files: map[string]*zip.File{}}
+	for _, f := range zr.File {
+		pkg.files[f.Name] = f
+	}
+	return pkg, nil
+}
+
+func (p *ooxml) has(name string) bool {
+	_, ok := p.files[name]
+	return ok
+}
+
+func (p *ooxml) read(name string) ([]byte, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, fmt.Errorf("missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// rels returns a part's relationships, mapping IDs to package paths
+func (p *ooxml) rels(part string) map[string]string {
+	relsPath := path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+	content, err := p.read(relsPath)
+	if err != nil {
+		return nil
+	}
+
+	var doc struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+	rels := map[string]string{}
+	for _, r := range doc.Relationships {
+		target := r.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(path.Dir(part), target)
+		}
+		rels[r.ID] = target
+	}
+	return rels
+}
+
+// walkXML calls fn for every token of an XML part
+func walkXML(content []byte, fn func(tok xml.Token)) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(tok)
+	}
+}
+
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// tableBuilder collects the rows and cells of a table
+type tableBuilder struct {
+	rows [][]string
+	row  []string
+	cell []string
+}
+
+func (t *tableBuilder) addText(text string) {
+	if text = strings.TrimSpace(text); text != "" {
+		t.cell = append(t.cell, text)
+	}
+}
+
+func (t *tableBuilder) endCell() {
+	t.row = append(t.row, strings.Join(t.cell, " "))
+	t.cell = nil
+}
+
+func (t *tableBuilder) endRow() {
+	t.rows = append(t.rows, t.row)
+	t.row = nil
+}
+
+func (t *tableBuilder) render() string {
+	rows := t.rows
+	t.rows = nil
+	return table(rows)
+}
@@ -0,0 +1,101 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+const wordNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+
+func TestDocx(t *testing.T) {
+	doc := `<w:document ` + wordNS + `><w:body>
+<w:p><w:pPr><w:pStyle w:val="Title"/></w:pPr><w:r><w:t>Kickoff</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t>Scope</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">ITSM and </w:t></w:r><w:r><w:t>CMDB.</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/></w:numPr></w:pPr><w:r><w:t>Discovery</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Name</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Role</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>Jane</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>CIO</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+</w:body></w:document>`
+	got, err := Bytes("kickoff.docx", buildZip(t, map[string]string{docxDocument: doc}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Kickoff\n\n## Scope\n\nITSM and CMDB.\n\n- Discovery\n\n| Name | Role |\n|---|---|\n| Jane | CIO |\n"
+	if got != want {
+		t.Errorf("docx =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestXlsx(t *testing.T) {
+	files := map[string]string{
+		xlsxWorkbook:                 `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Licenses" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		xlsxSharedStrings:            `<sst><si><t>Product</t></si><si><t>Price</t></si><si><t>ITSM Pro</t></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+<row r="1"><c r="B1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>Active</t></is></c></row>
+<row r="2"></row>
+<row r="3"><c r="B3" t="s"><v>2</v></c><c r="C3"><v>100.30000000000001</v></c><c r="D3" t="b"><v>1</v></c></row>
+</sheetData></worksheet>`,
+	}
+	got, err := Bytes("licenses.xlsx", buildZip(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "## Sheet: Licenses\n\n| Product | Price | Active |\n|---|---|---|\n| ITSM Pro | 100.3 | TRUE |\n"
+	if got != want {
+		t.Errorf("xlsx =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "C12": 2, "Z3": 25, "AA1": 26, "AB7": 27}
+	for ref, want := range tests {
+		if got, ok := columnIndex(ref); !ok || got != want {
+			t.Errorf("columnIndex(%q) = %d, %v, want %d", ref, got, ok, want)
+		}
+	}
+	if _, ok := columnIndex(""); ok {
+		t.Error("columnIndex(\"\") ok")
+	}
+}
+
+func TestPptx(t *testing.T) {
+	slide := func(title, bullet string) string {
+		return `<p:sld xmlns:p="p" xmlns:a="a"><p:cSld><p:spTree>
+<p:sp><p:nvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>` + title + `</a:t></a:r></a:p></p:txBody></p:sp>
+<p:sp><p:nvSpPr><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>` + bullet + `</a:t></a:r></a:p></p:txBody></p:sp>
+</p:spTree></p:cSld></p:sld>`
+	}
+	files := map[string]string{
+		pptxPresentation:                  `<p:presentation xmlns:p="p" xmlns:r="r"><p:sldIdLst><p:sldId r:id="rId2"/><p:sldId r:id="rId1"/></p:sldIdLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships><Relationship Id="rId1" Target="slides/slide1.xml"/><Relationship Id="rId2" Target="slides/slide2.xml"/></Relationships>`,
+		"ppt/slides/slide1.xml":           slide("Agenda", "Goals"),
+		"ppt/slides/slide2.xml":           slide("Welcome", "Introductions"),
+	}
+	got, err := Bytes("deck.pptx", buildZip(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "## Slide 1: Welcome\n\n- Introductions\n\n## Slide 2: Agenda\n\n- Goals\n"
+	if got != want {
+		t.Errorf("pptx =\n%s\nwant\n%s", got, want)
+	}
+}
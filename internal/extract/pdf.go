@@ -0,0 +1,358 @@
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+var (
+	pdfObject    = regexp.MustCompile(`\b\d+\s+\d+\s+obj\b`)
+	pdfStream    = regexp.MustCompile(`\bstream\r?\n`)
+	pdfLength    = regexp.MustCompile(`/Length\s+(\d+)(\s+\d+\s+R)?`)
+	pdfTextBlock = regexp.MustCompile(`\bBT\b`)
+	// pdfNonContent matches the dictionaries of streams that are not page
+	// content: images, cross-reference and object streams, metadata and
+	// embedded fonts
+	pdfNonContent = regexp.MustCompile(`/Subtype\s*/(Image|Type1C|CIDFontType0C|OpenType|XML)\b|/Type\s*/(XRef|ObjStm|Metadata)\b|/Length[123]\b`)
+	errScannedPDF = errors.New("no extractable text (the PDF may be scanned or use embedded font encodings)")
+)
+
+// pdfStreamObject is a stream with the dictionary of its own object
+type pdfStreamObject struct {
+	dict string
+	data []byte
+}
+
+// pdfStreams returns the streams of a PDF, reading each "N 0 obj ... endobj"
+// on its own so a dictionary never spans objects
+func pdfStreams(content []byte) []pdfStreamObject {
+	var streams []pdfStreamObject
+	for pos := 0; pos < len(content); {
+		loc := pdfObject.FindIndex(content[pos:])
+		if loc == nil {
+			break
+		}
+		body := pos + loc[1]
+		endobj := bytes.Index(content[body:], []byte("endobj"))
+		next := len(content)
+		if endobj >= 0 {
+			next = body + endobj + len("endobj")
+		}
+
+		start := pdfStream.FindIndex(content[body:])
+		if start == nil || (endobj >= 0 && start[0] > endobj) {
+			pos = next
+			continue
+		}
+		dict := string(content[body : body+start[0]])
+		dataStart := body + start[1]
+		dataEnd := -1
+		// A direct /Length is exact even when the data contains "endstream"
+		if m := pdfLength.FindStringSubmatch(dict); m != nil && m[2] == "" {
+			if n, err := strconv.Atoi(m[1]); err == nil && dataStart+n <= len(content) &&
+				bytes.HasPrefix(bytes.TrimLeft(content[dataStart+n:], "\r\n "), []byte("endstream")) {
+				dataEnd = dataStart + n
+			}
+		}
+		if dataEnd < 0 {
+			end := bytes.Index(content[dataStart:], []byte("endstream"))
+			if end < 0 {
+				break
+			}
+			dataEnd = dataStart + end
+		}
+		streams = append(streams, pdfStreamObject{dict: dict, data: content[dataStart:dataEnd]})
+
+		pos = dataEnd + len("endstream")
+		if endobj := bytes.Index(content[pos:], []byte("endobj")); endobj >= 0 {
+			pos += endobj + len("endobj")
+		}
+	}
+	return streams
+}
+
+// pdf extracts the text shown by a PDF's content streams. It handles the
+// common uncompressed and Flate-compressed streams with standard or UTF-16
+// strings; scanned pages and custom font encodings are not supported.
+func pdf(content []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(content), []byte("%PDF")) {
+		return "", errors.New("not a PDF file")
+	}
+
+	var out strings.Builder
+	for _, stream := range pdfStreams(content) {
+		dict, data := stream.dict, stream.data
+
+		if pdfNonContent.MatchString(dict) {
+			continue
+		}
+		if strings.Contains(dict, "/FlateDecode") {
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				continue
+			}
+			// Truncated streams still yield what was decoded
+			data, _ = io.ReadAll(r)
+		} else if strings.Contains(dict, "/Filter") {
+			continue
+		}
+		if !pdfTextBlock.Match(data) {
+			continue
+		}
+		if text := pdfText(data); strings.TrimSpace(text) != "" {
+			out.WriteString(text + "\n\n")
+		}
+	}
+
+	text := cleanPDFText(out.String())
+	if !readable(text) {
+		return "", errScannedPDF
+	}
+	return text, nil
+}
+
+// pdfText interprets the text operators of a content stream
+func pdfText(data []byte) string {
+	var out strings.Builder
+	var operands []string
+	var array []string
+	inArray := false
+
+	newline := func() {
+		if s := out.String(); s != "" && !strings.HasSuffix(s, "\n") {
+			out.WriteString("\n")
+		}
+	}
+
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '%':
+			for i < len(data) && data[i] != '\n' && data[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			s, n := pdfLiteral(data[i:])
+			i += n
+			if inArray {
+				array = append(array, s)
+			} else {
+				operands = append(operands, s)
+			}
+		case c == '<' && i+1 < len(data) && data[i+1] == '<':
+			i += 2
+		case c == '>' && i+1 < len(data) && data[i+1] == '>':
+			i += 2
+		case c == '<':
+			end := bytes.IndexByte(data[i:], '>')
+			if end < 0 {
+				i = len(data)
+				break
+			}
+			s := pdfHex(data[i+1 : i+end])
+			i += end + 1
+			if inArray {
+				array = append(array, s)
+			} else {
+				operands = append(operands, s)
+			}
+		case c == '[':
+			inArray, array = true, nil
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case isPDFSpace(c):
+			i++
+		default:
+			start := i
+			for i < len(data) && !isPDFSpace(data[i]) && !strings.ContainsRune("()<>[]/%", rune(data[i])) {
+				i++
+			}
+			if i == start {
+				// A name: skip the slash and read it as a token
+				i++
+				for i < len(data) && !isPDFSpace(data[i]) && !strings.ContainsRune("()<>[]/%", rune(data[i])) {
+					i++
+				}
+				operands = nil
+				continue
+			}
+			tok := string(data[start:i])
+			if f, err := strconv.ParseFloat(tok, 64); err == nil {
+				if inArray {
+					// Large negative kerning separates words
+					if f < -200 {
+						array = append(array, " ")
+					}
+				} else {
+					operands = append(operands, tok)
+				}
+				continue
+			}
+
+			switch tok {
+			case "Tj":
+				if len(operands) > 0 {
+					out.WriteString(operands[len(operands)-1])
+				}
+			case "'", "\"":
+				newline()
+				if len(operands) > 0 {
+					out.WriteString(operands[len(operands)-1])
+				}
+			case "TJ":
+				out.WriteString(strings.Join(array, ""))
+				array = nil
+			case "Td", "TD":
+				// Horizontal moves on the same line keep words apart
+				if len(operands) >= 2 && operands[len(operands)-1] == "0" {
+					out.WriteString(" ")
+				} else {
+					newline()
+				}
+			case "T*", "Tm", "ET":
+				newline()
+			}
+			operands = nil
+		}
+	}
+	return out.String()
+}
+
+// pdfLiteral decodes a literal string at the start of data, returning it
+// and the number of bytes consumed
+func pdfLiteral(data []byte) (string, int) {
+	var b []byte
+	depth := 0
+	i := 0
+	for ; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				b = append(b, c)
+			}
+			depth++
+			continue
+		case ')':
+			depth--
+			if depth == 0 {
+				return decodePDFString(b), i + 1
+			}
+			b = append(b, c)
+			continue
+		case '\\':
+			i++
+			if i >= len(data) {
+				break
+			}
+			switch e := data[i]; e {
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation
+				if e == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+					i++
+				}
+			default:
+				if e >= '0' && e <= '7' {
+					n := 0
+					j := 0
+					for ; j < 3 && i+j < len(data) && data[i+j] >= '0' && data[i+j] <= '7'; j++ {
+						n = n*8 + int(data[i+j]-'0')
+					}
+					i += j - 1
+					b = append(b, byte(n))
+				} else {
+					b = append(b, e)
+				}
+			}
+			continue
+		}
+		b = append(b, c)
+	}
+	return decodePDFString(b), i
+}
+
+func pdfHex(data []byte) string {
+	var digits []byte
+	for _, c := range data {
+		if !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	b := make([]byte, len(digits)/2)
+	for i := range b {
+		v, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		if err != nil {
+			return ""
+		}
+		b[i] = byte(v)
+	}
+	return decodePDFString(b)
+}
+
+// decodePDFString decodes UTF-16BE strings marked with a byte order mark and
+// treats the rest as Latin-1
+func decodePDFString(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFE && b[1] == 0xFF {
+		units := make([]uint16, 0, len(b)/2)
+		for i := 2; i+1 < len(b); i += 2 {
+			units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func cleanPDFText(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(strings.Join(strings.Fields(line), " "))
+	}
+	s = strings.Join(lines, "\n")
+	for strings.Contains(s, "\n\n\n") {
+		s = strings.ReplaceAll(s, "\n\n\n", "\n\n")
+	}
+	return strings.TrimSpace(s)
+}
+
+// readable reports whether most of the text is printable, which fails for
+// glyph IDs from fonts with custom encodings
+func readable(s string) bool {
+	total, good := 0, 0
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if unicode.IsPrint(r) && r != unicode.ReplacementChar {
+			good++
+		}
+	}
+	return total > 0 && good*10 >= total*9
+}
@@ -0,0 +1,105 @@
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF writes a one-page PDF in the usual object order: catalog, pages,
+// the page with its font resources, the font, then the content stream, with
+// a valid cross-reference table
+func buildPDF(content string, compress bool) []byte {
+	stream := []byte(content)
+	dict := ""
+	if compress {
+		var b bytes.Buffer
+		w := zlib.NewWriter(&b)
+		w.Write(stream)
+		w.Close()
+		stream = b.Bytes()
+		dict = " /Filter /FlateDecode"
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d%s >>\nstream\n%s\nendstream", len(stream), dict, stream),
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+func TestPDF(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		compress bool
+		want     string
+	}{
+		{"stream after page with font resources", "BT /F1 12 Tf 72 720 Td (Hello World) Tj ET", false, "Hello World"},
+		{"compressed", "BT /F1 12 Tf 72 720 Td (Hello World) Tj ET", true, "Hello World"},
+		{"lines", "BT /F1 12 Tf 72 720 Td (First line) Tj T* (Second line) Tj ET", false, "First line\nSecond line"},
+		{"kerning separates words", "BT /F1 12 Tf [(Hello) -250 (World)] TJ ET", false, "Hello World"},
+		{"kerning inside a word", "BT /F1 12 Tf [(Hel) -20 (lo)] TJ ET", false, "Hello"},
+		{"escapes", `BT /F1 12 Tf (Q\(1\) \101cme) Tj ET`, false, "Q(1) Acme"},
+		{"hex", "BT /F1 12 Tf <48656C6C6F> Tj ET", false, "Hello"},
+		{"utf-16", "BT /F1 12 Tf <FEFF00C90063006F006C0065> Tj ET", false, "École"},
+		{"stream containing endstream", "BT /F1 12 Tf (endstream here) Tj ET", false, "endstream here"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pdf(buildPDF(tt.content, tt.compress))
+			if err != nil {
+				t.Fatalf("pdf() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("pdf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPDFWithoutText(t *testing.T) {
+	_, err := pdf(buildPDF("q 612 0 0 792 0 0 cm /Im1 Do Q", false))
+	if !errors.Is(err, errScannedPDF) {
+		t.Errorf("pdf() error = %v, want %v", err, errScannedPDF)
+	}
+}
+
+func TestPDFNotAPDF(t *testing.T) {
+	if _, err := pdf([]byte("hello")); err == nil {
+		t.Error("pdf() accepted a file without the PDF header")
+	}
+}
+
+func TestPDFStreamsUseOwnDictionary(t *testing.T) {
+	streams := pdfStreams(buildPDF("BT (x) Tj ET", false))
+	if len(streams) != 1 {
+		t.Fatalf("found %d streams, want 1", len(streams))
+	}
+	if strings.Contains(streams[0].dict, "/Font") || strings.Contains(streams[0].dict, "/Page") {
+		t.Errorf("stream dictionary spans objects: %q", streams[0].dict)
+	}
+	if string(streams[0].data) != "BT (x) Tj ET" {
+		t.Errorf("stream data = %q", streams[0].data)
+	}
+}
@@ -0,0 +1,202 @@
+package extract
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const pptxPresentation = "ppt/presentation.xml"
+
+var slidePattern = regexp.MustCompile(`^ppt/slides/slide(\d+)\.xml$`)
+
+// pptx converts a presentation to Markdown: one section per slide with its
+// title, bullet points, tables and speaker notes
+func pptx(content []byte) (string, error) {
+	pkg, err := openOOXML(content)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	for i, slide := range pkg.slides() {
+		doc, err := pkg.read(slide)
+		if err != nil {
+			return "", err
+		}
+		s, err := parseSlide(doc)
+		if err != nil {
+			return "", fmt.Errorf("slide %d: %w", i+1, err)
+		}
+
+		fmt.Fprintf(&out, "## Slide %d", i+1)
+		if s.title != "" {
+			fmt.Fprintf(&out, ": %s", s.title)
+		}
+		out.WriteString("\n\n")
+		if s.body != "" {
+			out.WriteString(s.body + "\n")
+		}
+
+		for _, target := range pkg.rels(slide) {
+			if !strings.Contains(target, "notesSlide") {
+				continue
+			}
+			if notes, err := pkg.read(target); err == nil {
+				if text := notesText(notes); text != "" {
+					fmt.Fprintf(&out, "> Notes: %s\n\n", text)
+				}
+			}
+		}
+	}
+	return out.String(), nil
+}
+
+// slides returns the slide parts in presentation order, falling back to the
+// slide numbers in their names
+func (p *ooxml) slides() []string {
+	var slides []string
+	if doc, err := p.read(pptxPresentation); err == nil {
+		rels := p.rels(pptxPresentation)
+		walkXML(doc, func(tok xml.Token) {
+			if t, ok := tok.(xml.StartElement); ok && t.Name.Local == "sldId" {
+				if target, ok := rels[attr(t, "id")]; ok && p.has(target) {
+					slides = append(slides, target)
+				}
+			}
+		})
+	}
+	if len(slides) > 0 {
+		return slides
+	}
+
+	for name := range p.files {
+		if slidePattern.MatchString(name) {
+			slides = append(slides, name)
+		}
+	}
+	sort.Slice(slides, func(i, j int) bool {
+		a, _ := strconv.Atoi(slidePattern.FindStringSubmatch(slides[i])[1])
+		b, _ := strconv.Atoi(slidePattern.FindStringSubmatch(slides[j])[1])
+		return a < b
+	})
+	return slides
+}
+
+type slide struct {
+	title string
+	body  string
+}
+
+func parseSlide(doc []byte) (slide, error) {
+	var s slide
+	var body strings.Builder
+	var para strings.Builder
+	var tbl tableBuilder
+	var shape []string
+	isTitle, inText, inTable := false, false, false
+	level := 0
+
+	err := walkXML(doc, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sp":
+				shape, isTitle = nil, false
+			case "ph":
+				if typ := attr(t, "type"); typ == "title" || typ == "ctrTitle" {
+					isTitle = true
+				}
+			case "tbl":
+				inTable = true
+			case "p":
+				para.Reset()
+				level = 0
+			case "pPr":
+				level, _ = strconv.Atoi(attr(t, "lvl"))
+			case "t":
+				inText = true
+			case "br":
+				para.WriteString(" ")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text := oneLine(para.String())
+				switch {
+				case inTable:
+					tbl.addText(text)
+				case text != "" && isTitle:
+					shape = append(shape, text)
+				case text != "":
+					shape = append(shape, strings.Repeat("  ", level)+"- "+text)
+				}
+			case "sp":
+				if isTitle && s.title == "" {
+					s.title = strings.Join(shape, " ")
+				} else if len(shape) > 0 {
+					body.WriteString(strings.Join(shape, "\n") + "\n")
+				}
+				shape = nil
+			case "tc":
+				tbl.endCell()
+			case "tr":
+				tbl.endRow()
+			case "tbl":
+				inTable = false
+				body.WriteString("\n" + tbl.render())
+			}
+		case xml.CharData:
+			if inText {
+				para.Write(t)
+			}
+		}
+	})
+	s.body = strings.TrimSpace(body.String())
+	if s.body != "" {
+		s.body += "\n"
+	}
+	return s, err
+}
+
+// notesText returns the speaker notes on a notes slide, skipping the slide
+// image and number placeholders
+func notesText(doc []byte) string {
+	var parts []string
+	var para strings.Builder
+	inBody, inText := false, false
+	walkXML(doc, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sp":
+				inBody = false
+			case "ph":
+				inBody = attr(t, "type") == "body"
+			case "p":
+				para.Reset()
+			case "t":
+				inText = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if text := oneLine(para.String()); inBody && text != "" {
+					parts = append(parts, text)
+				}
+			}
+		case xml.CharData:
+			if inText {
+				para.Write(t)
+			}
+		}
+	})
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,230 @@
+package extract
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxWorkbook      = "xl/workbook.xml"
+	xlsxSharedStrings = "xl/sharedStrings.xml"
+
+	// maxSheetRows caps the rows rendered per sheet so large exports do not
+	// swamp a prompt
+	maxSheetRows = 500
+)
+
+// xlsx converts a workbook to Markdown: one table per sheet, the first
+// non-empty row being the header
+func xlsx(content []byte) (string, error) {
+	pkg, err := openOOXML(content)
+	if err != nil {
+		return "", err
+	}
+	workbook, err := pkg.read(xlsxWorkbook)
+	if err != nil {
+		return "", err
+	}
+	shared, err := sharedStrings(pkg)
+	if err != nil {
+		return "", err
+	}
+
+	rels := pkg.rels(xlsxWorkbook)
+	type sheet struct{ name, part string }
+	var sheets []sheet
+	walkXML(workbook, func(tok xml.Token) {
+		if t, ok := tok.(xml.StartElement); ok && t.Name.Local == "sheet" {
+			if part, ok := rels[attr(t, "id")]; ok {
+				sheets = append(sheets, sheet{attr(t, "name"), part})
+			}
+		}
+	})
+
+	var out strings.Builder
+	for _, s := range sheets {
+		doc, err := pkg.read(s.part)
+		if err != nil {
+			return "", err
+		}
+		rows, err := sheetRows(doc, shared)
+		if err != nil {
+			return "", fmt.Errorf("sheet %s: %w", s.name, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&out, "## Sheet: %s\n\n", s.name)
+		truncated := len(rows) - 1 - maxSheetRows
+		if truncated > 0 {
+			rows = rows[:maxSheetRows+1]
+		}
+		out.WriteString(table(rows))
+		if truncated > 0 {
+			fmt.Fprintf(&out, "\n_%d more rows not shown_\n", truncated)
+		}
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
+
+func sharedStrings(pkg *ooxml) ([]string, error) {
+	if !pkg.has(xlsxSharedStrings) {
+		return nil, nil
+	}
+	doc, err := pkg.read(xlsxSharedStrings)
+	if err != nil {
+		return nil, err
+	}
+
+	var strs []string
+	var b strings.Builder
+	inText, inPhonetic := false, false
+	err = walkXML(doc, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				b.Reset()
+			case "rPh":
+				inPhonetic = true
+			case "t":
+				inText = !inPhonetic
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				strs = append(strs, b.String())
+			case "rPh":
+				inPhonetic = false
+			case "t":
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	})
+	return strs, err
+}
+
+// sheetRows returns a sheet's cell values by row and column, with empty rows
+// and leading and trailing empty columns removed
+func sheetRows(doc []byte, shared []string) ([][]string, error) {
+	var rows [][]string
+	var row []string
+	var value strings.Builder
+	var ref, typ string
+	inValue := false
+
+	err := walkXML(doc, func(tok xml.Token) {
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				row = nil
+			case "c":
+				ref, typ = attr(t, "r"), attr(t, "t")
+				value.Reset()
+			case "v", "t":
+				inValue = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "c":
+				col := len(row)
+				if c, ok := columnIndex(ref); ok {
+					col = c
+				}
+				for len(row) <= col {
+					row = append(row, "")
+				}
+				row[col] = cellValue(value.String(), typ, shared)
+			case "row":
+				rows = append(rows, row)
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+		}
+	})
+	return trimRows(rows), err
+}
+
+func cellValue(v, typ string, shared []string) string {
+	switch typ {
+	case "s":
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 && i < len(shared) {
+			return shared[i]
+		}
+		return ""
+	case "b":
+		if v == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	case "e", "str", "inlineStr":
+		return v
+	}
+	// Numbers are stored with binary float noise, e.g. 100.30000000000001,
+	// which Excel hides by showing 15 significant digits
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', 15, 64), 64)
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return v
+}
+
+// columnIndex returns the zero-based column of a cell reference like "C12"
+func columnIndex(ref string) (int, bool) {
+	col := 0
+	for i, r := range ref {
+		if r < 'A' || r > 'Z' {
+			return col - 1, i > 0
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1, ref != ""
+}
+
+func trimRows(rows [][]string) [][]string {
+	var kept [][]string
+	for _, row := range rows {
+		for _, cell := range row {
+			if strings.TrimSpace(cell) != "" {
+				kept = append(kept, row)
+				break
+			}
+		}
+	}
+
+	first, last := -1, -1
+	for _, row := range kept {
+		for j, cell := range row {
+			if strings.TrimSpace(cell) == "" {
+				continue
+			}
+			if first < 0 || j < first {
+				first = j
+			}
+			last = max(last, j)
+		}
+	}
+	for i, row := range kept {
+		trimmed := make([]string, last-first+1)
+		for j := range trimmed {
+			if first+j < len(row) {
+				trimmed[j] = row[first+j]
+			}
+		}
+		kept[i] = trimmed
+	}
+	return kept
+}
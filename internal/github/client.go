@@ -1,6 +1,8 @@
 package github
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +23,7 @@ const (
 type GitHubFile struct {
 	Name        string `json:"name"`
 	Type        string `json:"type"`
+	SHA         string `json:"sha"`
 	DownloadURL string `json:"download_url"`
 }
 
@@ -29,44 +32,62 @@ type GitHubRepo struct {
 	HTMLURL  string `json:"html_url"`
 }
 
-// FetchAndSavePrompts fetches prompts from GitHub and saves them
-func FetchAndSavePrompts(projectPath string) error {
+// BlobSHA returns the git blob SHA of content, as reported by the GitHub
+// contents API, so local files can be compared with recorded prompt versions
+func BlobSHA(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FetchAndSavePrompts fetches prompts from GitHub and saves them. When include
+// is set, only prompts it accepts are saved. It returns the upstream blob SHA
+// of each saved prompt.
+func FetchAndSavePrompts(projectPath string, include func(name string, content []byte) bool) (map[string]string, error) {
 	resp, err := http.Get(GitHubBaseURL)
 	if err != nil {
-		return fmt.Errorf("failed to fetch prompts: %w", err)
+		return nil, fmt.Errorf("failed to fetch prompts: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
 	}
 
 	var files []GitHubFile
 	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	promptsPath := filepath.Join(projectPath, "10_PromptTemplates")
+	saved := map[string]string{}
 
 	for _, file := range files {
 		if file.Type == "file" && strings.HasSuffix(file.Name, ".md") {
 			content, err := downloadFile(file.DownloadURL)
 			if err != nil {
-				return fmt.Errorf("failed to download %s: %w", file.Name, err)
+				return nil, fmt.Errorf("failed to download %s: %w", file.Name, err)
+			}
+
+			if include != nil && !include(file.Name, content) {
+				continue
 			}
 
 			filePath := filepath.Join(promptsPath, file.Name)
 			if err := os.WriteFile(filePath, content, 0644); err != nil {
-				return fmt.Errorf("failed to save %s: %w", file.Name, err)
+				return nil, fmt.Errorf("failed to save %s: %w", file.Name, err)
 			}
+			saved[file.Name] = file.SHA
 		}
 	}
 
-	return nil
+	return saved, nil
 }
 
-// FetchCommunicationTemplates fetches communication templates
-func FetchCommunicationTemplates(projectPath string) error {
+// FetchCommunicationTemplates fetches communication templates into
+// templatesPath, keeping any that already exist
+func FetchCommunicationTemplates(templatesPath string) error {
 	templates := []struct {
 		URL      string
 		Filename string
@@ -77,16 +98,19 @@ func FetchCommunicationTemplates(projectPath string) error {
 		},
 	}
 
-	templatesPath := filepath.Join(projectPath, "30_CommunicationTemplates")
-
 	for _, template := range templates {
+		filePath := filepath.Join(templatesPath, template.Filename)
+		if _, err := os.Stat(filePath); err == nil {
+			// Keep templates the user already has
+			continue
+		}
+
 		content, err := downloadFile(template.URL)
 		if err != nil {
 			// Just warn, don't fail
 			continue
 		}
 
-		filePath := filepath.Join(templatesPath, template.Filename)
 		if err := os.WriteFile(filePath, content, 0644); err != nil {
 			continue
 		}
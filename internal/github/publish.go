@@ -0,0 +1,226 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// BasePromptsRepo is the repository the base prompts are fetched from
+	BasePromptsRepo = "Now-SC-Base-Prompts"
+	// BasePromptsDir is the folder holding prompts inside BasePromptsRepo
+	BasePromptsDir = "Prompts"
+)
+
+// UpstreamFile is a file's content and blob SHA in the upstream repository
+type UpstreamFile struct {
+	Path    string
+	SHA     string
+	Content []byte
+	// Exists is false when the file is not present upstream yet
+	Exists bool
+}
+
+// PublishRequest describes a local prompt change to propose upstream
+type PublishRequest struct {
+	Filename string
+	Content  []byte
+	Upstream *UpstreamFile
+	Title    string
+	Body     string
+	Branch   string
+}
+
+// PullRequest is the subset of the GitHub pull request response we use
+type PullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+type repoInfo struct {
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Permissions struct {
+		Push bool `json:"push"`
+	} `json:"permissions"`
+}
+
+// FetchUpstreamPrompt fetches a prompt from the base prompts repository
+func FetchUpstreamPrompt(filename string) (*UpstreamFile, error) {
+	path := BasePromptsDir + "/" + filename
+	file := &UpstreamFile{Path: path}
+
+	var content struct {
+		SHA      string `json:"sha"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	status, err := apiRequest(os.Getenv("GITHUB_PAT"), http.MethodGet,
+		fmt.Sprintf("%s/repos/%s/%s/contents/%s", GitHubAPIURL, GitHubOrg, BasePromptsRepo, path), nil, &content)
+	if status == http.StatusNotFound {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch upstream %s: %w", filename, err)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode upstream %s: %w", filename, err)
+	}
+
+	file.SHA = content.SHA
+	file.Content = decoded
+	file.Exists = true
+	return file, nil
+}
+
+// PublishPrompt commits a prompt change to a branch of the base prompts
+// repository, or of the user's fork when they cannot push to it, and opens a
+// pull request against the upstream default branch
+func PublishPrompt(req PublishRequest) (*PullRequest, error) {
+	token := os.Getenv("GITHUB_PAT")
+	if token == "" {
+		return nil, fmt.Errorf("GITHUB_PAT environment variable not set")
+	}
+
+	upstreamURL := fmt.Sprintf("%s/repos/%s/%s", GitHubAPIURL, GitHubOrg, BasePromptsRepo)
+
+	var upstream repoInfo
+	if _, err := apiRequest(token, http.MethodGet, upstreamURL, nil, &upstream); err != nil {
+		return nil, fmt.Errorf("failed to read upstream repository: %w", err)
+	}
+
+	// Branch in the source repo when possible, otherwise in a fork
+	target := upstream
+	if !upstream.Permissions.Push {
+		fmt.Printf("Note: No push access to %s. Using a fork instead...\n", upstream.FullName)
+		fork, err := ensureFork(token, upstreamURL)
+		if err != nil {
+			return nil, err
+		}
+		target = *fork
+	}
+	targetURL := fmt.Sprintf("%s/repos/%s", GitHubAPIURL, target.FullName)
+
+	var baseRef struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if _, err := apiRequest(token, http.MethodGet, fmt.Sprintf("%s/git/ref/heads/%s", upstreamURL, upstream.DefaultBranch), nil, &baseRef); err != nil {
+		return nil, fmt.Errorf("failed to read %s branch: %w", upstream.DefaultBranch, err)
+	}
+
+	refBody := map[string]string{
+		"ref": "refs/heads/" + req.Branch,
+		"sha": baseRef.Object.SHA,
+	}
+	if _, err := apiRequest(token, http.MethodPost, targetURL+"/git/refs", refBody, nil); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", req.Branch, err)
+	}
+
+	commitBody := map[string]string{
+		"message": req.Title,
+		"content": base64.StdEncoding.EncodeToString(req.Content),
+		"branch":  req.Branch,
+	}
+	if req.Upstream != nil && req.Upstream.Exists {
+		commitBody["sha"] = req.Upstream.SHA
+	}
+	if _, err := apiRequest(token, http.MethodPut, targetURL+"/contents/"+BasePromptsDir+"/"+req.Filename, commitBody, nil); err != nil {
+		return nil, fmt.Errorf("failed to commit %s: %w", req.Filename, err)
+	}
+
+	head := req.Branch
+	if target.FullName != upstream.FullName {
+		head = target.Owner.Login + ":" + req.Branch
+	}
+
+	prBody := map[string]interface{}{
+		"title": req.Title,
+		"head":  head,
+		"base":  upstream.DefaultBranch,
+		"body":  req.Body,
+	}
+	var pr PullRequest
+	if _, err := apiRequest(token, http.MethodPost, upstreamURL+"/pulls", prBody, &pr); err != nil {
+		return nil, fmt.Errorf("failed to open pull request: %w", err)
+	}
+
+	return &pr, nil
+}
+
+func ensureFork(token, upstreamURL string) (*repoInfo, error) {
+	var fork repoInfo
+	if _, err := apiRequest(token, http.MethodPost, upstreamURL+"/forks", map[string]interface{}{}, &fork); err != nil {
+		return nil, fmt.Errorf("failed to fork repository: %w", err)
+	}
+
+	// Forking is asynchronous; wait until the fork answers
+	forkURL := fmt.Sprintf("%s/repos/%s", GitHubAPIURL, fork.FullName)
+	for attempt := 0; attempt < 10; attempt++ {
+		if status, err := apiRequest(token, http.MethodGet, forkURL, nil, &fork); err == nil && status == http.StatusOK {
+			return &fork, nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	return nil, fmt.Errorf("fork %s is not ready yet, try again shortly", fork.FullName)
+}
+
+// apiRequest calls the GitHub API, decoding a JSON response into out when set.
+// It returns the response status alongside any error.
+func apiRequest(token, method, url string, body interface{}, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,282 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+)
+
+const (
+	// File is the index of prompt runs, one JSON entry per line, inside the
+	// project's metadata folder
+	File = "history.jsonl"
+
+	// Commands that record runs
+	CommandPrompt     = "prompt"
+	CommandWatch      = "watch"
+	CommandRegenerate = "regenerate"
+	CommandOverview   = "overview"
+	CommandActions    = "actions"
+
+	// maxInput caps the characters of user input kept in an entry
+	maxInput = 200
+
+	// lockTimeout is how long Append waits for another run to finish
+	// writing, and staleLock the age after which a lock left behind by a
+	// crashed run is taken over
+	lockTimeout = 10 * time.Second
+	staleLock   = time.Minute
+)
+
+// Entry is a prompt run that saved an output
+type Entry struct {
+	ID       int       `json:"id"`
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Template string    `json:"template"`
+	Workflow string    `json:"workflow,omitempty"`
+	Provider string    `json:"provider"`
+	Model    string    `json:"model"`
+	// Output and Inputs are relative to the project root
+	Output string   `json:"output"`
+	Inputs []string `json:"inputs,omitempty"`
+	// Input is the start of what the user entered for the prompt
+	Input    string            `json:"input,omitempty"`
+	Customer string            `json:"customer,omitempty"`
+	Usage    *provenance.Usage `json:"usage,omitempty"`
+	// RegeneratedFrom is the output this run made a new version of
+	RegeneratedFrom string `json:"regenerated_from,omitempty"`
+}
+
+// Tokens returns the total tokens the run used, 0 when not reported
+func (e *Entry) Tokens() int {
+	if e.Usage == nil {
+		return 0
+	}
+	return e.Usage.TotalTokens
+}
+
+// NewEntry describes a run from the provenance of the output it saved. The
+// customer is the one named in the output path, then the inputs and the user
+// input.
+func NewEntry(m *project.Manifest, command, output string, p *provenance.Provenance) Entry {
+	e := Entry{
+		Time:            p.Generated,
+		Command:         command,
+		Template:        p.Template.Path,
+		Workflow:        p.Parameters.Workflow,
+		Provider:        p.Provider,
+		Model:           p.Model,
+		Output:          provenance.RelPath(m.Root(), output),
+		Input:           shorten(p.Parameters.Input),
+		Usage:           p.Usage,
+		RegeneratedFrom: p.RegeneratedFrom,
+	}
+	texts := []string{e.Output}
+	for _, input := range p.Inputs {
+		e.Inputs = append(e.Inputs, input.Path)
+		texts = append(texts, input.Path)
+	}
+	e.Customer = ingest.Customer(m.Customers, append(texts, p.Parameters.Input)...)
+	return e
+}
+
+// Record appends a run to the project's history
+func Record(m *project.Manifest, command, output string, p *provenance.Provenance) error {
+	return Append(m, NewEntry(m, command, output, p))
+}
+
+// RecordRun appends a run whose output carries no provenance, such as an
+// overview update. The template is a path relative to the project root or a
+// built-in prompt such as "<overview>"; output and inputs are relative to the
+// project root.
+func RecordRun(m *project.Manifest, command, template, providerName, model, output string, inputs []string) error {
+	e := Entry{
+		Time:     time.Now().UTC().Truncate(time.Second),
+		Command:  command,
+		Template: filepath.ToSlash(template),
+		Provider: providerName,
+		Model:    model,
+		Output:   filepath.ToSlash(output),
+	}
+	for _, input := range inputs {
+		e.Inputs = append(e.Inputs, filepath.ToSlash(input))
+	}
+	e.Customer = ingest.Customer(m.Customers, e.Inputs...)
+	return Append(m, e)
+}
+
+// Load reads a project's history, oldest first; a missing file is an empty
+// history. Lines that are not valid entries, e.g. one cut short by a crash,
+// are skipped and described in skipped.
+func Load(m *project.Manifest) (entries []Entry, skipped []string, err error) {
+	f, err := os.Open(m.Path(project.MetaDir, File))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			skipped = append(skipped, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, skipped, nil
+}
+
+// Append adds an entry to the project's history, numbering it after the
+// highest ID. The history is locked while it is numbered and written, so
+// concurrent runs get distinct IDs.
+func Append(m *project.Manifest, e Entry) error {
+	if err := os.MkdirAll(m.Path(project.MetaDir), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", project.MetaDir, err)
+	}
+	unlock, err := lock(m.Path(project.MetaDir, File))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, _, err := Load(m)
+	if err != nil {
+		return err
+	}
+	e.ID = 1
+	for _, existing := range entries {
+		if existing.ID >= e.ID {
+			e.ID = existing.ID + 1
+		}
+	}
+	var line bytes.Buffer
+	enc := json.NewEncoder(&line)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(e); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(m.Path(project.MetaDir, File), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := f.Write(line.Bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
+
+// lock takes a lock file next to path, waiting while another run holds it,
+// and returns the function that releases it
+func lock(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock history: %w", err)
+		}
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("history is locked by another run; remove %s if none is running", lockPath)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// Filter selects entries; empty fields match everything
+type Filter struct {
+	// Template matches part of the template path, ignoring case
+	Template string
+	// Customer matches the customer's name, ignoring case
+	Customer string
+	// Since and Until bound the run time, inclusive
+	Since time.Time
+	Until time.Time
+}
+
+// Match reports whether an entry passes the filter
+func (f Filter) Match(e Entry) bool {
+	if f.Template != "" && !strings.Contains(strings.ToLower(e.Template), strings.ToLower(f.Template)) {
+		return false
+	}
+	if f.Customer != "" && !strings.EqualFold(e.Customer, f.Customer) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Select returns the entries passing the filter, newest first
+func Select(entries []Entry, f Filter) []Entry {
+	var selected []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if f.Match(entries[i]) {
+			selected = append(selected, entries[i])
+		}
+	}
+	return selected
+}
+
+// Find returns an entry by ID, or the latest run that saved an output path
+// relative to the project root
+func Find(entries []Entry, ref string) (*Entry, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i := range entries {
+			if entries[i].ID == id {
+				return &entries[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no history entry %d", id)
+	}
+	output := filepath.ToSlash(filepath.Clean(ref))
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Output == output {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no history entry for %s", ref)
+}
+
+func shorten(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len([]rune(s)) <= maxInput {
+		return s
+	}
+	return string([]rune(s)[:maxInput]) + "…"
+}
@@ -0,0 +1,138 @@
+package history
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+func newProject(t *testing.T) *project.Manifest {
+	t.Helper()
+	m := project.NewManifest(t.TempDir(), "proj")
+	m.Customers = []project.Customer{{Name: "Acme Corp", Dir: "Acme_Corp"}}
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestLoadSkipsInvalidLines(t *testing.T) {
+	m := newProject(t)
+	content := `{"id":1,"command":"prompt","output":"a.md"}
+{"id":2,"command":"prompt","out
+not json
+
+{"id":3,"command":"watch","output":"b.md"}
+`
+	if err := os.WriteFile(m.Path(project.MetaDir, File), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, skipped, err := Load(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != 1 || entries[1].ID != 3 {
+		t.Errorf("got entries %+v", entries)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("got skipped %v, want lines 2 and 3", skipped)
+	}
+
+	// New entries are numbered after the highest valid ID
+	if err := Append(m, Entry{Command: CommandPrompt, Output: "c.md"}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _, err = Load(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := entries[len(entries)-1]; last.ID != 4 || last.Output != "c.md" {
+		t.Errorf("got %+v", last)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	entries, skipped, err := Load(newProject(t))
+	if err != nil || entries != nil || skipped != nil {
+		t.Errorf("got %v, %v, %v", entries, skipped, err)
+	}
+}
+
+func TestAppendConcurrently(t *testing.T) {
+	m := newProject(t)
+	const runs = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, runs)
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- Append(m, Entry{Command: CommandWatch, Output: "out.md"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, skipped, err := Load(m)
+	if err != nil || len(skipped) > 0 {
+		t.Fatalf("got %v, %v", skipped, err)
+	}
+	seen := map[int]bool{}
+	for _, e := range entries {
+		if seen[e.ID] {
+			t.Errorf("duplicate ID %d", e.ID)
+		}
+		seen[e.ID] = true
+	}
+	if len(entries) != runs {
+		t.Errorf("got %d entries, want %d", len(entries), runs)
+	}
+	if _, err := os.Stat(m.Path(project.MetaDir, File+".lock")); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestAppendTakesOverStaleLock(t *testing.T) {
+	m := newProject(t)
+	lockPath := m.Path(project.MetaDir, File+".lock")
+	if err := os.WriteFile(lockPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLock)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := Append(m, Entry{Command: CommandPrompt, Output: "a.md"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordRun(t *testing.T) {
+	m := newProject(t)
+	err := RecordRun(m, CommandActions, "<actions>", "fake", "fake-model", "actions.yaml",
+		[]string{"01_Customers/Acme_Corp/2025-01-15_kickoff.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, _, err := Load(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries", len(entries))
+	}
+	e := entries[0]
+	if e.ID != 1 || e.Command != CommandActions || e.Template != "<actions>" || e.Output != "actions.yaml" ||
+		e.Model != "fake-model" || e.Customer != "Acme Corp" || e.Time.IsZero() {
+		t.Errorf("got %+v", e)
+	}
+}
@@ -0,0 +1,288 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+// Kinds of inbox material
+const (
+	KindTranscript = "transcript"
+	KindEmail      = "email"
+	KindNote       = "note"
+	KindDeck       = "deck"
+)
+
+// Audiences of a call
+const (
+	AudienceInternal = "internal"
+	AudienceExternal = "external"
+)
+
+// Methods used to classify a file
+const (
+	MethodRules = "rules"
+	MethodLLM   = "llm"
+)
+
+// sniffSize is how much of a file is read to detect its kind
+const sniffSize = 64 * 1024
+
+// llmExcerpt is how much text is sent to the model for classification
+const llmExcerpt = 6000
+
+var (
+	cuePattern     = regexp.MustCompile(`(?m)^\[?\d{1,2}:\d{2}(?::\d{2})?(?:[.,]\d{1,3})?\]?`)
+	speakerPattern = regexp.MustCompile(`(?m)^[\p{Lu}][\p{L}.' -]{1,40}:\s+\S`)
+	headerPattern  = regexp.MustCompile(`(?mi)^(from|to|subject|date|cc):\s`)
+	wordPattern    = func(word string) *regexp.Regexp {
+		return regexp.MustCompile(`(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(word) + `([^\pL\pN]|$)`)
+	}
+	internalWord = wordPattern("internal")
+	externalWord = wordPattern("external")
+)
+
+// Classification is what a file was recognised as
+type Classification struct {
+	Kind     string `json:"kind"`
+	Audience string `json:"audience,omitempty"`
+	Customer string `json:"customer,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Method   string `json:"method"`
+	Reason   string `json:"reason"`
+}
+
+// detectKind recognises a file from its extension, name and content
+func detectKind(name string, head []byte) (kind, reason string) {
+	ext := strings.ToLower(filepath.Ext(name))
+	lower := strings.ToLower(name)
+
+	switch ext {
+	case ".vtt", ".srt":
+		return KindTranscript, fmt.Sprintf("%s subtitle file", strings.TrimPrefix(ext, "."))
+	case ".eml", ".mbox", ".msg":
+		return KindEmail, fmt.Sprintf("%s mail file", strings.TrimPrefix(ext, "."))
+	case ".pptx", ".ppt", ".key", ".odp":
+		return KindDeck, "presentation file"
+	case ".pdf":
+		if containsAny(lower, "deck", "slides", "presentation", "pitch") {
+			return KindDeck, "PDF named like a deck"
+		}
+		return KindNote, "PDF document"
+	}
+
+	if !isText(head) {
+		if containsAny(lower, "transcript", "recording") {
+			return KindTranscript, "named like a transcript"
+		}
+		return KindNote, "document"
+	}
+
+	text := string(head)
+	switch {
+	case strings.HasPrefix(strings.TrimPrefix(text, "\uFEFF"), "WEBVTT"):
+		return KindTranscript, "WebVTT content"
+	case len(headerPattern.FindAllString(firstLines(text, 20), -1)) >= 2:
+		return KindEmail, "starts with mail headers"
+	case len(cuePattern.FindAllString(text, -1)) >= 3:
+		return KindTranscript, "timestamped lines"
+	case len(speakerPattern.FindAllString(text, -1)) >= 5:
+		return KindTranscript, "speaker turns"
+	case containsAny(lower, "transcript", "recording"):
+		return KindTranscript, "named like a transcript"
+	}
+	return KindNote, "plain text"
+}
+
+// detectAudience decides whether a call was internal or external from its
+// name, its content and the customers it mentions
+func detectAudience(name string, head []byte, customer string) (audience, reason string) {
+	switch {
+	case internalWord.MatchString(name):
+		return AudienceInternal, "named internal"
+	case externalWord.MatchString(name):
+		return AudienceExternal, "named external"
+	}
+	if isText(head) {
+		text := firstLines(string(head), 30)
+		switch {
+		case internalWord.MatchString(text):
+			return AudienceInternal, "marked internal"
+		case externalWord.MatchString(text):
+			return AudienceExternal, "marked external"
+		}
+	}
+	if customer != "" && mentions(name, project.Customer{Name: customer}) {
+		return AudienceExternal, "named after the customer"
+	}
+	return "", "audience unknown"
+}
+
+// detectCustomer tags the customer a file is about: one named in the file
+// name, then in its content, then the project's only customer
+func detectCustomer(name string, head []byte, customers []project.Customer) (customer, reason string) {
+	// Longest names first, so "Acme Europe" wins over "Acme"
+	customers = append([]project.Customer{}, customers...)
+	sort.SliceStable(customers, func(i, j int) bool { return len(customers[i].Name) > len(customers[j].Name) })
+	for _, c := range customers {
+		if mentions(name, c) {
+			return c.Name, "customer in file name"
+		}
+	}
+	if isText(head) {
+		for _, c := range customers {
+			if mentions(string(head), c) {
+				return c.Name, "customer in content"
+			}
+		}
+	}
+	if len(customers) == 1 {
+		return customers[0].Name, "only customer"
+	}
+	return "", ""
+}
+
+// Customer returns the customer named in the first text that names one,
+// longest names first, then the project's only customer
+func Customer(customers []project.Customer, texts ...string) string {
+	customers = append([]project.Customer{}, customers...)
+	sort.SliceStable(customers, func(i, j int) bool { return len(customers[i].Name) > len(customers[j].Name) })
+	for _, text := range texts {
+		for _, c := range customers {
+			if mentions(text, c) {
+				return c.Name
+			}
+		}
+	}
+	if len(customers) == 1 {
+		return customers[0].Name
+	}
+	return ""
+}
+
+func mentions(text string, c project.Customer) bool {
+	for _, value := range []string{c.Name, c.Dir, strings.ReplaceAll(c.Dir, "_", " ")} {
+		if value != "" && wordPattern(value).MatchString(strings.ReplaceAll(text, "_", " ")) {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyWithLLM asks the model to classify a text file
+func classifyWithLLM(p provider.Provider, name string, head []byte, customers []project.Customer) (*Classification, error) {
+	names := make([]string, len(customers))
+	for i, c := range customers {
+		names[i] = c.Name
+	}
+
+	system := fmt.Sprintf(`You sort material collected by a ServiceNow presales consultant.
+Classify the document you are given.
+
+- kind: "transcript" (a meeting or call transcript), "email", "deck" (slides) or "note"
+- audience: for transcripts, "internal" if only the consultant's colleagues took part,
+  "external" if the customer or a partner took part, "" if you cannot tell
+- customer: which of these customers it is about, exactly as written, or "": %s
+- title: a short title of 2 to 6 words
+- reason: one sentence explaining the classification
+
+Reply with a single JSON object with the keys kind, audience, customer, title and reason, and nothing else.`,
+		strings.Join(names, ", "))
+
+	excerpt := string(head)
+	if len(excerpt) > llmExcerpt {
+		excerpt = strings.ToValidUTF8(excerpt[:llmExcerpt], "")
+	}
+	response, err := p.ExecutePrompt(system, fmt.Sprintf("File name: %s\n\n%s", name, excerpt))
+	if err != nil {
+		return nil, err
+	}
+
+	var c Classification
+	if err := json.Unmarshal([]byte(extractJSON(response)), &c); err != nil {
+		return nil, fmt.Errorf("model returned an invalid classification: %w", err)
+	}
+
+	switch c.Kind {
+	case KindTranscript, KindEmail, KindNote, KindDeck:
+	default:
+		return nil, fmt.Errorf("model returned an unknown kind %q", c.Kind)
+	}
+	if c.Audience != AudienceInternal && c.Audience != AudienceExternal {
+		c.Audience = ""
+	}
+	known := ""
+	for _, customer := range customers {
+		if strings.EqualFold(customer.Name, c.Customer) {
+			known = customer.Name
+		}
+	}
+	c.Customer = known
+	c.Method = MethodLLM
+	return &c, nil
+}
+
+// extractJSON returns the JSON object in a model response, which may be
+// wrapped in a code fence or prose
+func extractJSON(response string) string {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return response
+	}
+	return response[start : end+1]
+}
+
+// emailDate returns the Date header of a mail file
+func emailDate(head []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(head))
+	if err != nil {
+		return ""
+	}
+	date, err := msg.Header.Date()
+	if err != nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func isText(head []byte) bool {
+	if bytes.IndexByte(head, 0) >= 0 {
+		return false
+	}
+	// The head may cut a multi-byte character short
+	for i := 0; i < utf8.UTFMax && len(head) > 0; i++ {
+		if utf8.Valid(head) {
+			return true
+		}
+		head = head[:len(head)-1]
+	}
+	return utf8.Valid(head)
+}
+
+func firstLines(text string, n int) string {
+	lines := strings.SplitN(text, "\n", n+1)
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func containsAny(s string, words ...string) bool {
+	for _, word := range words {
+		if strings.Contains(s, word) {
+			return true
+		}
+	}
+	return false
+}
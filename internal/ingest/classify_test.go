@@ -0,0 +1,107 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+func TestDetectKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"kickoff.vtt", "", KindTranscript},
+		{"kickoff.srt", "", KindTranscript},
+		{"pricing.eml", "", KindEmail},
+		{"export.mbox", "", KindEmail},
+		{"Acme pitch.pptx", "", KindDeck},
+		{"Acme_deck.pdf", "", KindDeck},
+		{"RFP.pdf", "", KindNote},
+		{"call.m4a", "\x00\x01", KindNote},
+		{"recording.m4a", "\x00\x01", KindTranscript},
+		{"kickoff.txt", "\uFEFFWEBVTT\n\n00:00.000 --> 00:01.000\nHi", KindTranscript},
+		{"pricing.txt", "From: jane@acme.com\nSubject: Pricing\n\nHello", KindEmail},
+		{"call.txt", "[00:01] Hi\n[00:05] Hello\n[00:09] Agenda", KindTranscript},
+		{"call.txt", "Jane: hi\nJohn: hello\nJane: agenda\nJohn: ok\nJane: next", KindTranscript},
+		{"sync transcript.txt", "We talked.", KindTranscript},
+		{"ideas.md", "# Ideas\n\nA list: of things", KindNote},
+	}
+	for _, tt := range tests {
+		if got, reason := detectKind(tt.name, []byte(tt.content)); got != tt.want {
+			t.Errorf("detectKind(%q) = %s (%s), want %s", tt.name, got, reason, tt.want)
+		}
+	}
+}
+
+func TestDetectAudience(t *testing.T) {
+	tests := []struct {
+		name, content, customer, want string
+	}{
+		{"internal_sync.vtt", "", "", AudienceInternal},
+		{"External-review.vtt", "", "", AudienceExternal},
+		{"international.vtt", "", "", ""},
+		{"sync.vtt", "WEBVTT\nNOTE internal prep call", "", AudienceInternal},
+		{"Acme_kickoff.vtt", "WEBVTT", "Acme", AudienceExternal},
+		{"kickoff.vtt", "WEBVTT", "Acme", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := detectAudience(tt.name, []byte(tt.content), tt.customer); got != tt.want {
+			t.Errorf("detectAudience(%q, %q) = %q, want %q", tt.name, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestDetectCustomer(t *testing.T) {
+	customers := []project.Customer{{Name: "Acme", Dir: "Acme"}, {Name: "Acme Europe", Dir: "Acme_Europe"}, {Name: "Globex", Dir: "Globex"}}
+	tests := []struct {
+		name, content, want string
+	}{
+		{"Acme_Europe_kickoff.txt", "", "Acme Europe"},
+		{"acme kickoff.txt", "Globex", "Acme"},
+		{"kickoff.txt", "Notes about Globex.", "Globex"},
+		{"kickoff.txt", "Notes about Globexy.", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := detectCustomer(tt.name, []byte(tt.content), customers); got != tt.want {
+			t.Errorf("detectCustomer(%q, %q) = %q, want %q", tt.name, tt.content, got, tt.want)
+		}
+	}
+	if got, reason := detectCustomer("kickoff.txt", nil, customers[:1]); got != "Acme" || reason != "only customer" {
+		t.Errorf("single customer = %q (%s)", got, reason)
+	}
+}
+
+// scriptedProvider answers every prompt with a fixed response
+type scriptedProvider struct {
+	provider.FakeProvider
+	response string
+}
+
+func (p *scriptedProvider) ExecutePrompt(promptContent, userInput string) (string, error) {
+	return p.response, nil
+}
+
+func TestClassifyWithLLM(t *testing.T) {
+	customers := []project.Customer{{Name: "Acme Corp", Dir: "Acme_Corp"}}
+	p := &scriptedProvider{response: "Here you go:\n```json\n" +
+		`{"kind": "transcript", "audience": "both", "customer": "acme corp", "title": "Pricing call", "reason": "A call."}` + "\n```"}
+	c, err := classifyWithLLM(p, "call.txt", []byte("text"), customers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Kind != KindTranscript || c.Audience != "" || c.Customer != "Acme Corp" || c.Title != "Pricing call" || c.Method != MethodLLM {
+		t.Errorf("classification = %+v", c)
+	}
+
+	p.response = `{"kind": "memo", "customer": "Unknown"}`
+	if _, err := classifyWithLLM(p, "call.txt", []byte("text"), customers); err == nil || !strings.Contains(err.Error(), `unknown kind "memo"`) {
+		t.Errorf("classifyWithLLM with an unknown kind = %v", err)
+	}
+	if _, err := classifyWithLLM(&provider.FakeProvider{}, "call.txt", []byte("text"), customers); err == nil {
+		t.Error("classifyWithLLM accepted a response without JSON")
+	}
+}
@@ -0,0 +1,316 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/email"
+	"github.com/Now-AI-Foundry/Now-SC/internal/extract"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/Now-AI-Foundry/Now-SC/internal/transcript"
+)
+
+// LogFile records every ingest decision, one JSON object per line, inside
+// the project's metadata folder
+const LogFile = "ingest.log"
+
+// Layout roles of the folders each kind of material is filed into
+var kindRoles = map[string]string{
+	KindTranscript: project.RoleCalls,
+	KindEmail:      project.RoleEmails,
+	KindNote:       project.RoleNotes,
+	KindDeck:       project.RoleDecks,
+}
+
+var (
+	datePattern   = regexp.MustCompile(`(20\d{2})[-_.]?(0[1-9]|1[0-2])[-_.]?(0[1-9]|[12]\d|3[01])`)
+	datedFilename = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}_`)
+)
+
+// Folder returns the inbox folder, relative to the project root, that a kind
+// of material is filed into. Calls are split by audience when it is known.
+func Folder(layout *project.Layout, kind, audience string) string {
+	dir := layout.RoleDir(kindRoles[kind])
+	if kind == KindTranscript && audience != "" {
+		dir = filepath.Join(dir, audience)
+	}
+	return dir
+}
+
+// Decision is how one inbox file was filed
+type Decision struct {
+	Time time.Time `json:"time"`
+	// Source and Dest are relative to the project root
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+	Date   string `json:"date"`
+	Classification
+	// Markdown lists the clean Markdown versions written next to the file
+	Markdown []string `json:"markdown,omitempty"`
+	// Error is set when the model could not classify the file and the rules
+	// were used instead
+	Error string `json:"error,omitempty"`
+}
+
+// Ingester classifies and files new material dropped into a project's inbox
+type Ingester struct {
+	Manifest *project.Manifest
+	Layout   *project.Layout
+	// Provider classifies text files with a model; nil uses rules only
+	Provider provider.Provider
+
+	// planned holds destinations already given out, so files planned in one
+	// run do not collide
+	planned map[string]bool
+}
+
+// Pending returns the files waiting at the top of the inbox, relative to
+// the project root. Hidden files and READMEs are ignored.
+func (in *Ingester) Pending() ([]string, error) {
+	inbox := in.Layout.InboxDir()
+	if inbox == "" {
+		return nil, fmt.Errorf("the project layout has no inbox folder")
+	}
+
+	entries, err := os.ReadDir(in.Manifest.Path(inbox))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inbox: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.EqualFold(name, "README.md") || extract.IsCache(name) {
+			continue
+		}
+		files = append(files, filepath.Join(inbox, name))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Plan classifies a file and decides where it goes, without moving it. The
+// file is relative to the project root.
+func (in *Ingester) Plan(file string) (*Decision, error) {
+	full := in.Manifest.Path(file)
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, err
+	}
+	head, err := readHead(full)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(file)
+	d := &Decision{Time: time.Now().UTC().Truncate(time.Second), Source: filepath.ToSlash(file)}
+
+	var reasons []string
+	kind, reason := detectKind(name, head)
+	d.Kind, d.Method = kind, MethodRules
+	reasons = append(reasons, reason)
+
+	customer, reason := detectCustomer(name, head, in.Manifest.Customers)
+	d.Customer = customer
+	if reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if kind == KindTranscript {
+		d.Audience, reason = detectAudience(name, head, customer)
+		reasons = append(reasons, reason)
+	}
+	d.Reason = strings.Join(reasons, "; ")
+
+	if in.Provider != nil && isText(head) {
+		c, err := classifyWithLLM(in.Provider, name, head, in.Manifest.Customers)
+		if err != nil {
+			d.Error = err.Error()
+		} else {
+			if c.Customer == "" {
+				c.Customer = d.Customer
+			}
+			d.Classification = *c
+		}
+	}
+	if d.Kind != KindTranscript {
+		d.Audience = ""
+	}
+
+	d.Date = fileDate(name, head, d.Kind, info.ModTime())
+	d.Dest = filepath.ToSlash(in.destination(file, d))
+	return d, nil
+}
+
+// Apply moves a planned file into place and records the decision in the
+// ingest log
+func (in *Ingester) Apply(d *Decision) error {
+	dest := in.Manifest.Path(filepath.FromSlash(d.Dest))
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(d.Dest), err)
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", d.Dest)
+	}
+	if err := os.Rename(in.Manifest.Path(filepath.FromSlash(d.Source)), dest); err != nil {
+		return fmt.Errorf("failed to move %s: %w", d.Source, err)
+	}
+	// A cache left by attaching the file from the inbox is rebuilt next to it
+	os.Remove(extract.CachePath(in.Manifest.Path(filepath.FromSlash(d.Source))))
+
+	outputs, err := convert(dest, d.Kind)
+	if err != nil {
+		return err
+	}
+	for _, output := range outputs {
+		d.Markdown = append(d.Markdown, path.Join(path.Dir(d.Dest), filepath.Base(output)))
+	}
+	return AppendLog(in.Manifest, d)
+}
+
+// convert writes Markdown versions of a filed transcript, mail file or
+// document next to it for use as prompt context, returning their paths.
+// Files that cannot be parsed are left as they are.
+func convert(file, kind string) ([]string, error) {
+	dir := filepath.Dir(file)
+	switch {
+	case kind == KindTranscript && strings.ToLower(filepath.Ext(file)) != ".md":
+		t, err := transcript.ParseFile(file)
+		if err != nil {
+			return nil, nil
+		}
+		md := filepath.Join(dir, transcript.MarkdownName(file))
+		if _, err := os.Stat(md); err == nil {
+			return nil, nil
+		}
+		if err := os.WriteFile(md, t.Markdown(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", md, err)
+		}
+		return []string{md}, nil
+
+	case kind == KindEmail && email.IsMailFile(file):
+		messages, err := email.ParseFile(file)
+		if err != nil {
+			return nil, nil
+		}
+		var outputs []string
+		for _, thread := range email.Threads(messages) {
+			md, err := thread.Write(dir)
+			if err != nil {
+				return outputs, err
+			}
+			outputs = append(outputs, md)
+		}
+		return outputs, nil
+
+	case extract.Supported(file):
+		if _, err := extract.Cached(file); err != nil {
+			return nil, nil
+		}
+		return []string{extract.CachePath(file)}, nil
+	}
+	return nil, nil
+}
+
+// AppendLog adds a decision to the project's ingest log
+func AppendLog(m *project.Manifest, d *Decision) error {
+	line, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	path := m.Path(project.MetaDir, LogFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open ingest log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write ingest log: %w", err)
+	}
+	return nil
+}
+
+// destination returns the inbox path for a file: the kind's folder, split
+// into internal and external for calls, and a name like
+// 2024-05-02_Acme_Corp_Kickoff_call.vtt. A free name is picked if it is taken.
+func (in *Ingester) destination(file string, d *Decision) string {
+	dir := Folder(in.Layout, d.Kind, d.Audience)
+
+	name := filepath.Base(file)
+	ext := strings.ToLower(filepath.Ext(name))
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+
+	if !datedFilename.MatchString(base) {
+		title := d.Title
+		if title == "" {
+			title = datePattern.ReplaceAllString(base, "")
+		}
+		parts := []string{d.Date}
+		if i := in.Manifest.FindCustomer(d.Customer); i >= 0 && !mentions(title, in.Manifest.Customers[i]) {
+			parts = append(parts, in.Manifest.Customers[i].Dir)
+		}
+		if slug, err := project.Slug(title); err == nil {
+			parts = append(parts, slug)
+		} else {
+			parts = append(parts, d.Kind)
+		}
+		base = strings.Join(parts, "_")
+	}
+
+	candidate := filepath.Join(dir, base+ext)
+	for i := 2; in.planned[candidate] || project.FileExists(in.Manifest.Root(), candidate); i++ {
+		candidate = filepath.Join(dir, fmt.Sprintf("%s_%d%s", base, i, ext))
+	}
+	if in.planned == nil {
+		in.planned = map[string]bool{}
+	}
+	in.planned[candidate] = true
+	return candidate
+}
+
+// fileDate returns the date of the material: from the file name, an email's
+// Date header, or the file's modification time
+func fileDate(name string, head []byte, kind string, modTime time.Time) string {
+	if m := datePattern.FindStringSubmatch(name); m != nil {
+		return fmt.Sprintf("%s-%s-%s", m[1], m[2], m[3])
+	}
+	if kind == KindEmail {
+		if date := emailDate(head); date != "" {
+			return date
+		}
+	}
+	return modTime.Format("2006-01-02")
+}
+
+func readHead(path string) ([]byte, error) {
+	// Documents are classified by their extracted text when there is any
+	if extract.Supported(path) {
+		if text, err := extract.File(path); err == nil {
+			return []byte(text[:min(len(text), sniffSize)]), nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return head[:n], nil
+}
@@ -0,0 +1,236 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+var modTime = time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+
+func newIngester(t *testing.T) *Ingester {
+	t.Helper()
+	m := project.NewManifest(t.TempDir(), "proj")
+	m.Customers = []project.Customer{{Name: "Acme Corp", Dir: "Acme_Corp"}, {Name: "Globex", Dir: "Globex"}}
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return &Ingester{Manifest: m, Layout: project.DefaultLayout()}
+}
+
+// writeInbox writes a file relative to the project root, dated modTime
+func writeInbox(t *testing.T, m *project.Manifest, rel, content string) string {
+	t.Helper()
+	path := m.Path(filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.FromSlash(rel)
+}
+
+func TestPending(t *testing.T) {
+	in := newIngester(t)
+	writeInbox(t, in.Manifest, "00_Inbox/b.txt", "b")
+	writeInbox(t, in.Manifest, "00_Inbox/a.txt", "a")
+	writeInbox(t, in.Manifest, "00_Inbox/.DS_Store", "")
+	writeInbox(t, in.Manifest, "00_Inbox/README.md", "")
+	writeInbox(t, in.Manifest, "00_Inbox/RFP.pdf.md", "")
+	writeInbox(t, in.Manifest, "00_Inbox/notes/filed.md", "")
+
+	files, err := in.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.FromSlash("00_Inbox/a.txt"), filepath.FromSlash("00_Inbox/b.txt")}
+	if strings.Join(files, " ") != strings.Join(want, " ") {
+		t.Errorf("Pending = %q, want %q", files, want)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	in := newIngester(t)
+	tests := []struct {
+		file, content                  string
+		kind, audience, customer, dest string
+	}{
+		{"00_Inbox/pricing notes.txt", "Pricing discussion with Acme Corp.", KindNote, "", "Acme Corp",
+			"00_Inbox/notes/2025-01-15_Acme_Corp_pricing_notes.txt"},
+		{"00_Inbox/Globex sync 20250120.txt", "Notes", KindNote, "", "Globex",
+			"00_Inbox/notes/2025-01-20_Globex_sync.txt"},
+		{"00_Inbox/2025-01-02_internal_prep.vtt", "WEBVTT\n\n00:00.000 --> 00:02.000\n<v Jane>Hi", KindTranscript, AudienceInternal, "",
+			"00_Inbox/calls/internal/2025-01-02_internal_prep.vtt"},
+		{"00_Inbox/Acme Corp kickoff.vtt", "WEBVTT", KindTranscript, AudienceExternal, "Acme Corp",
+			"00_Inbox/calls/external/2025-01-15_Acme_Corp_kickoff.vtt"},
+		{"00_Inbox/pricing.eml", "From: jane@acme.com\nSubject: Pricing\nDate: Mon, 6 Jan 2025 09:00:00 +0000\n\nHello", KindEmail, "", "",
+			"00_Inbox/emails/2025-01-06_pricing.eml"},
+		{"00_Inbox/Globex pitch.pptx", "not a real deck", KindDeck, "", "Globex",
+			"00_Inbox/decks/2025-01-15_Globex_pitch.pptx"},
+	}
+	for _, tt := range tests {
+		file := writeInbox(t, in.Manifest, tt.file, tt.content)
+		d, err := in.Plan(file)
+		if err != nil {
+			t.Fatalf("Plan(%s): %v", tt.file, err)
+		}
+		if d.Kind != tt.kind || d.Audience != tt.audience || d.Customer != tt.customer || d.Dest != tt.dest || d.Method != MethodRules {
+			t.Errorf("Plan(%s) = %s/%s/%s -> %s (%s), want %s/%s/%s -> %s", tt.file, d.Kind, d.Audience, d.Customer, d.Dest, d.Method,
+				tt.kind, tt.audience, tt.customer, tt.dest)
+		}
+	}
+}
+
+func TestPlanPicksFreeNames(t *testing.T) {
+	in := newIngester(t)
+	writeInbox(t, in.Manifest, "00_Inbox/notes/2025-01-15_Globex_sync.txt", "filed earlier")
+	first := writeInbox(t, in.Manifest, "00_Inbox/Globex sync.txt", "a")
+	second := writeInbox(t, in.Manifest, "00_Inbox/Globex_sync.txt", "b")
+
+	var dests []string
+	for _, file := range []string{first, second} {
+		d, err := in.Plan(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dests = append(dests, d.Dest)
+	}
+	want := "00_Inbox/notes/2025-01-15_Globex_sync_2.txt 00_Inbox/notes/2025-01-15_Globex_sync_3.txt"
+	if got := strings.Join(dests, " "); got != want {
+		t.Errorf("destinations = %s, want %s", got, want)
+	}
+}
+
+func TestPlanWithModel(t *testing.T) {
+	in := newIngester(t)
+	file := writeInbox(t, in.Manifest, "00_Inbox/call.txt", "We spoke about pricing.")
+
+	in.Provider = &scriptedProvider{response: `{"kind": "transcript", "audience": "external", "customer": "", "title": "Pricing call", "reason": "A call."}`}
+	d, err := in.Plan(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The customer falls back to the rules when the model names none
+	if d.Method != MethodLLM || d.Dest != "00_Inbox/calls/external/2025-01-15_Pricing_call.txt" {
+		t.Errorf("Plan with the model = %+v", d)
+	}
+
+	// A model that returns no classification leaves the rules' decision
+	in.Provider = &provider.FakeProvider{}
+	if d, err = in.Plan(file); err != nil {
+		t.Fatal(err)
+	}
+	if d.Method != MethodRules || d.Kind != KindNote || d.Error == "" {
+		t.Errorf("Plan with a failing model = %+v", d)
+	}
+}
+
+func TestApplyLogsDecisions(t *testing.T) {
+	in := newIngester(t)
+	vtt := writeInbox(t, in.Manifest, "00_Inbox/2025-01-02_internal_prep.vtt", "WEBVTT\n\n00:00.000 --> 00:02.000\n<v Jane>Hi team")
+	note := writeInbox(t, in.Manifest, "00_Inbox/pricing.txt", "Pricing")
+
+	for _, file := range []string{vtt, note} {
+		d, err := in.Plan(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := in.Apply(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if project.FileExists(in.Manifest.Root(), vtt) ||
+		!project.FileExists(in.Manifest.Root(), "00_Inbox/calls/internal/2025-01-02_internal_prep.vtt") ||
+		!project.FileExists(in.Manifest.Root(), "00_Inbox/calls/internal/2025-01-02_internal_prep.md") {
+		t.Error("transcript was not moved and converted")
+	}
+
+	f, err := os.Open(in.Manifest.Path(project.MetaDir, LogFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var logged []Decision
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var d Decision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			t.Fatalf("invalid log line %q: %v", scanner.Text(), err)
+		}
+		logged = append(logged, d)
+	}
+	if len(logged) != 2 {
+		t.Fatalf("logged %d decisions, want 2", len(logged))
+	}
+	first := logged[0]
+	if first.Source != "00_Inbox/2025-01-02_internal_prep.vtt" || first.Kind != KindTranscript || first.Audience != AudienceInternal ||
+		first.Date != "2025-01-02" || first.Method != MethodRules || first.Reason == "" ||
+		strings.Join(first.Markdown, " ") != "00_Inbox/calls/internal/2025-01-02_internal_prep.md" {
+		t.Errorf("logged %+v", first)
+	}
+	if logged[1].Dest != "00_Inbox/notes/2025-01-15_pricing.txt" {
+		t.Errorf("logged %+v", logged[1])
+	}
+
+	// An occupied destination is not overwritten
+	again := writeInbox(t, in.Manifest, "00_Inbox/pricing.txt", "Pricing again")
+	d, err := in.Plan(again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Dest = logged[1].Dest
+	if err := in.Apply(d); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Apply onto an existing file = %v", err)
+	}
+}
+
+func TestSkipAndMaterial(t *testing.T) {
+	in := newIngester(t)
+	m, layout := in.Manifest, in.Layout
+	for _, rel := range []string{
+		"00_Inbox/notes/pricing.md",
+		"00_Inbox/notes/README.md",
+		"00_Inbox/notes/RFP.pdf",
+		"00_Inbox/notes/RFP.pdf.md",
+		"00_Inbox/.trash/old.md",
+		"00_Inbox/calls/kickoff.vtt",
+		"00_Inbox/calls/kickoff.md",
+		"00_Inbox/calls/sync.vtt",
+		"00_Inbox/emails/pricing.md",
+		"00_Inbox/emails/pricing_attachments/quote.pdf",
+		"99_Assets/Summaries/pricing_summary.md",
+	} {
+		writeInbox(t, m, rel, "text")
+	}
+
+	files, err := Material(m, layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, file := range files {
+		got = append(got, filepath.ToSlash(file))
+	}
+	want := "00_Inbox/calls/kickoff.md 00_Inbox/calls/sync.vtt 00_Inbox/emails/pricing.md 00_Inbox/notes/RFP.pdf 00_Inbox/notes/pricing.md"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Material = %q, want %s", got, want)
+	}
+
+	if !Skip(m, layout, filepath.FromSlash("99_Assets/Summaries/pricing_summary.md")) {
+		t.Error("Skip accepted a file outside the inbox")
+	}
+	if !Skip(m, layout, filepath.FromSlash("00_Inbox/calls/kickoff.vtt")) {
+		t.Error("Skip accepted a transcript that has a Markdown version")
+	}
+}
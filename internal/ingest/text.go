@@ -0,0 +1,137 @@
+package ingest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/email"
+	"github.com/Now-AI-Foundry/Now-SC/internal/extract"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/transcript"
+)
+
+// Text returns a file's content as Markdown for use as prompt context:
+// transcripts are reduced to speaker turns, mail files to threads and
+// documents to their extracted text. Other files must be text.
+func Text(file string) (string, error) {
+	switch {
+	case email.IsMailFile(file):
+		messages, err := email.ParseFile(file)
+		if err != nil {
+			return "", err
+		}
+		var threads []string
+		for _, thread := range email.Threads(messages) {
+			threads = append(threads, string(thread.Markdown()))
+		}
+		return strings.Join(threads, "\n"), nil
+
+	case transcript.IsTranscriptFile(file):
+		t, err := transcript.ParseFile(file)
+		if err != nil {
+			return "", err
+		}
+		return string(t.Markdown()), nil
+
+	case extract.Supported(file):
+		return extract.Cached(file)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		return "", errors.New("not a text file")
+	}
+	return string(content), nil
+}
+
+// Attachment is a file attached to a prompt as context
+type Attachment struct {
+	Name    string
+	Content string
+}
+
+// ReadAttachments reads files attached as prompt context with Text
+func ReadAttachments(files []string) ([]Attachment, error) {
+	var attachments []Attachment
+	for _, file := range files {
+		content, err := Text(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot attach %s: %w", file, err)
+		}
+		attachments = append(attachments, Attachment{Name: filepath.Base(file), Content: content})
+	}
+	return attachments, nil
+}
+
+// WithAttachments appends attached files to the user input
+func WithAttachments(userInput string, attachments []Attachment) string {
+	if len(attachments) == 0 {
+		return userInput
+	}
+	var b strings.Builder
+	b.WriteString(userInput)
+	b.WriteString("\n\n## Attached Context\n")
+	for _, a := range attachments {
+		fmt.Fprintf(&b, "\n### %s\n\n%s\n", a.Name, strings.TrimSpace(a.Content))
+	}
+	return b.String()
+}
+
+// Skip reports whether an inbox file, relative to the project root, is not
+// material of its own: hidden files, READMEs, extraction caches, transcripts
+// converted to Markdown next to them and mail attachments
+func Skip(m *project.Manifest, layout *project.Layout, file string) bool {
+	inbox, err := filepath.Rel(layout.InboxDir(), file)
+	if err != nil || strings.HasPrefix(inbox, "..") {
+		return true
+	}
+	for _, part := range strings.Split(filepath.ToSlash(inbox), "/") {
+		if strings.HasPrefix(part, ".") || strings.HasSuffix(part, email.AttachmentsSuffix) {
+			return true
+		}
+	}
+	name := filepath.Base(file)
+	if strings.EqualFold(name, "README.md") || extract.IsCache(name) {
+		return true
+	}
+	// Converted transcripts are read through their Markdown version
+	ext := filepath.Ext(name)
+	return !strings.EqualFold(ext, ".md") && project.FileExists(m.Root(), strings.TrimSuffix(file, ext)+".md")
+}
+
+// Material returns the inbox files that are material of their own, relative
+// to the project root
+func Material(m *project.Manifest, layout *project.Layout) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(m.Path(layout.InboxDir()), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(m.Root(), p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && rel != filepath.Clean(layout.InboxDir()) && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() && !Skip(m, layout, rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inbox: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -15,6 +15,7 @@ const (
 
 type Client struct {
 	apiKey string
+	model  string
 	client *http.Client
 }
 
@@ -24,34 +25,108 @@ type Message struct {
 }
 
 type Request struct {
-	Model    string    `json:"model"`
-	Messages []Message `json:"messages"`
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat asks the model for output matching a JSON schema
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema names the schema structured output must follow
+type JSONSchema struct {
+	Name   string `json:"name"`
+	Strict bool   `json:"strict"`
+	Schema any    `json:"schema"`
 }
 
 type Response struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage Usage `json:"usage"`
+}
+
+// Usage is the number of tokens a request consumed
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Completion is a response with the model that produced it and the tokens
+// it used
+type Completion struct {
+	Content string
+	Model   string
+	Usage   Usage
 }
 
 // NewClient creates a new OpenRouter client
 func NewClient(apiKey string) *Client {
+	return NewClientWithModel(apiKey, DefaultModel)
+}
+
+// NewClientWithModel creates a new OpenRouter client that uses the given model
+func NewClientWithModel(apiKey, model string) *Client {
+	if model == "" {
+		model = DefaultModel
+	}
 	return &Client{
 		apiKey: apiKey,
+		model:  model,
 		client: &http.Client{},
 	}
 }
 
+// Model returns the model used by the client
+func (c *Client) Model() string {
+	return c.model
+}
+
 // ExecutePrompt executes a prompt using OpenRouter API
 func (c *Client) ExecutePrompt(promptContent, userInput string) (string, error) {
+	completion, err := c.Complete(promptContent, userInput)
+	if err != nil {
+		return "", err
+	}
+	return completion.Content, nil
+}
+
+// Complete executes a prompt, returning the response with its model and
+// token usage
+func (c *Client) Complete(promptContent, userInput string) (*Completion, error) {
+	return c.execute(c.request(promptContent, userInput))
+}
+
+// ExecuteStructured executes a prompt whose response must be JSON matching
+// the schema, returning the JSON
+func (c *Client) ExecuteStructured(promptContent, userInput, schemaName string, schema any) (string, error) {
+	req := c.request(promptContent, userInput)
+	req.ResponseFormat = &ResponseFormat{
+		Type:       "json_schema",
+		JSONSchema: &JSONSchema{Name: schemaName, Strict: true, Schema: schema},
+	}
+	completion, err := c.execute(req)
+	if err != nil {
+		return "", err
+	}
+	return completion.Content, nil
+}
+
+func (c *Client) request(promptContent, userInput string) Request {
 	if userInput == "" {
 		userInput = "Please provide guidance based on the system prompt."
 	}
 
-	reqBody := Request{
-		Model: DefaultModel,
+	return Request{
+		Model: c.model,
 		Messages: []Message{
 			{
 				Role:    "system",
@@ -63,15 +138,17 @@ func (c *Client) ExecutePrompt(promptContent, userInput string) (string, error)
 			},
 		},
 	}
+}
 
+func (c *Client) execute(reqBody Request) (*Completion, error) {
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", OpenRouterAPIURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
@@ -81,23 +158,31 @@ func (c *Client) ExecutePrompt(promptContent, userInput string) (string, error)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("OpenRouter API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("OpenRouter API error (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	var apiResp Response
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if len(apiResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from API")
+		return nil, fmt.Errorf("no response from API")
 	}
 
-	return apiResp.Choices[0].Message.Content, nil
+	completion := &Completion{
+		Content: apiResp.Choices[0].Message.Content,
+		Model:   apiResp.Model,
+		Usage:   apiResp.Usage,
+	}
+	if completion.Model == "" {
+		completion.Model = c.model
+	}
+	return completion, nil
 }
@@ -0,0 +1,235 @@
+package overview
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/diff"
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+const (
+	// FileName is the living project overview, in the layout's overview
+	// folder
+	FileName = "Project_Overview.md"
+	// versionsFolder keeps prior versions of the overview and the diff each
+	// update made, next to the overview
+	versionsFolder = "versions"
+	// StateFile records what the overview incorporates, inside the project's
+	// metadata folder
+	StateFile = "overview.json"
+
+	// MaxMaterial caps the characters of new material sent in one update;
+	// the rest waits for the next update
+	MaxMaterial = 200_000
+)
+
+const updatePrompt = `You maintain the living project overview for a ServiceNow pre-sales engagement.
+
+You are given the current overview (empty for a new project) and new material from the project inbox: call transcripts, emails, notes and documents. Return the complete updated overview in Markdown.
+
+Keep this structure:
+# Project Overview
+## Customer and Context
+## Stakeholders
+## Business Drivers and Pain Points
+## Scope and Requirements
+## Timeline and Milestones
+## Risks and Open Questions
+## Decisions
+## Next Steps
+
+Rules:
+- Fold the new material into the existing sections; keep what is still true and correct what the new material contradicts.
+- Note where important facts came from by file name, e.g. (2025-01-15_Acme_kickoff.md).
+- Do not invent facts. Leave a section with "Nothing yet." when there is nothing for it.
+- Return only the overview document.`
+
+// State is what the overview incorporates
+type State struct {
+	Version int       `json:"version"`
+	Updated time.Time `json:"updated"`
+	Model   string    `json:"model,omitempty"`
+	// Incorporated maps inbox files to the hash of the version incorporated
+	Incorporated map[string]string `json:"incorporated"`
+}
+
+// LoadState reads a project's overview state; a missing file is an empty
+// state
+func LoadState(m *project.Manifest) (*State, error) {
+	s := &State{Incorporated: map[string]string{}}
+	content, err := os.ReadFile(m.Path(project.MetaDir, StateFile))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overview state: %w", err)
+	}
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf("invalid overview state: %w", err)
+	}
+	if s.Incorporated == nil {
+		s.Incorporated = map[string]string{}
+	}
+	return s, nil
+}
+
+// Save writes the state to the project's metadata folder
+func (s *State) Save(m *project.Manifest) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.Path(project.MetaDir), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", project.MetaDir, err)
+	}
+	if err := os.WriteFile(m.Path(project.MetaDir, StateFile), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write overview state: %w", err)
+	}
+	return nil
+}
+
+// File returns the living project overview, relative to the project root
+func File(layout *project.Layout) string {
+	return filepath.ToSlash(filepath.Join(layout.RoleDir(project.RoleOverview), FileName))
+}
+
+// VersionsDir returns the folder keeping prior versions of the overview,
+// relative to the project root
+func VersionsDir(layout *project.Layout) string {
+	return filepath.ToSlash(filepath.Join(layout.RoleDir(project.RoleOverview), versionsFolder))
+}
+
+// Material is an inbox file not yet incorporated in its current version
+type Material struct {
+	// File is relative to the project root
+	File    string
+	Hash    string
+	Content string
+}
+
+// Pending returns the inbox material that is new or changed since it was
+// incorporated. Files that cannot be read as text, such as scanned PDFs, are
+// left out and described in skipped.
+func Pending(m *project.Manifest, layout *project.Layout, s *State) (pending []Material, skipped []string, err error) {
+	files, err := ingest.Material(m, layout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(m.Path(file))
+		if err != nil {
+			return nil, nil, err
+		}
+		hash := provenance.Hash(content)
+		key := filepath.ToSlash(file)
+		if s.Incorporated[key] == hash {
+			continue
+		}
+		text, err := ingest.Text(m.Path(file))
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		pending = append(pending, Material{File: key, Hash: hash, Content: text})
+	}
+	return pending, skipped, nil
+}
+
+// Result is the outcome of an update
+type Result struct {
+	Version int
+	// Incorporated and Deferred list the material included in this update
+	// and left for the next one
+	Incorporated []string
+	Deferred     []string
+	// Previous is where the prior version was kept, empty for the first
+	// version
+	Previous string
+	// Diff is the unified diff from the prior version, and DiffFile where it
+	// was saved
+	Diff     string
+	DiffFile string
+}
+
+// Update sends the current overview and the pending material to the model
+// and writes the updated overview, keeping the prior version and its diff.
+// system overrides the built-in prompt when set.
+func Update(p provider.Provider, m *project.Manifest, layout *project.Layout, s *State, pending []Material, system string) (*Result, error) {
+	if system == "" {
+		system = updatePrompt
+	}
+	file, versionsDir := File(layout), VersionsDir(layout)
+	current := ""
+	if content, err := os.ReadFile(m.Path(file)); err == nil {
+		current = string(content)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read overview: %w", err)
+	}
+
+	result := &Result{Version: s.Version + 1}
+	var input strings.Builder
+	input.WriteString("## Current Overview\n\n")
+	if strings.TrimSpace(current) == "" {
+		input.WriteString("(none yet)\n")
+	} else {
+		input.WriteString(strings.TrimSpace(current) + "\n")
+	}
+	input.WriteString("\n## New Material\n")
+	size := 0
+	var included []Material
+	for _, material := range pending {
+		if len(included) > 0 && size+len(material.Content) > MaxMaterial {
+			result.Deferred = append(result.Deferred, material.File)
+			continue
+		}
+		size += len(material.Content)
+		included = append(included, material)
+		result.Incorporated = append(result.Incorporated, material.File)
+		fmt.Fprintf(&input, "\n### %s\n\n%s\n", material.File, strings.TrimSpace(material.Content))
+	}
+
+	response, err := p.ExecutePrompt(system, input.String())
+	if err != nil {
+		return nil, err
+	}
+	updated := strings.TrimSpace(response) + "\n"
+
+	if current != "" {
+		result.Previous = filepath.ToSlash(filepath.Join(versionsDir, fmt.Sprintf("Project_Overview_v%d.md", s.Version)))
+		result.DiffFile = filepath.ToSlash(filepath.Join(versionsDir, fmt.Sprintf("Project_Overview_v%d.diff", result.Version)))
+		result.Diff = diff.Unified(current, updated, fmt.Sprintf("v%d", s.Version), fmt.Sprintf("v%d", result.Version))
+		if err := os.MkdirAll(m.Path(versionsDir), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", versionsDir, err)
+		}
+		if err := os.WriteFile(m.Path(result.Previous), []byte(current), 0644); err != nil {
+			return nil, fmt.Errorf("failed to keep the previous overview: %w", err)
+		}
+		if err := os.WriteFile(m.Path(result.DiffFile), []byte(result.Diff), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write the overview diff: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.Path(file)), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(m.Path(file), []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write overview: %w", err)
+	}
+
+	s.Version = result.Version
+	s.Updated = time.Now().UTC().Truncate(time.Second)
+	s.Model = p.Model()
+	for _, material := range included {
+		s.Incorporated[material.File] = material.Hash
+	}
+	return result, s.Save(m)
+}
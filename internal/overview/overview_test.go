@@ -0,0 +1,167 @@
+package overview
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+func newProject(t *testing.T) (*project.Manifest, *project.Layout) {
+	t.Helper()
+	m := project.NewManifest(t.TempDir(), "proj")
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return m, project.DefaultLayout()
+}
+
+func writeFile(t *testing.T, m *project.Manifest, rel, content string) {
+	t.Helper()
+	path := m.Path(filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPending(t *testing.T) {
+	m, layout := newProject(t)
+	writeFile(t, m, "00_Inbox/notes/kickoff.md", "Kickoff notes")
+	writeFile(t, m, "00_Inbox/notes/scan.bin", "\x00\x01\x02")
+
+	s := &State{Incorporated: map[string]string{}}
+	pending, skipped, err := Pending(m, layout, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].File != "00_Inbox/notes/kickoff.md" || pending[0].Content != "Kickoff notes" {
+		t.Errorf("pending = %+v", pending)
+	}
+	if pending[0].Hash != provenance.Hash([]byte("Kickoff notes")) {
+		t.Errorf("hash = %s", pending[0].Hash)
+	}
+	if len(skipped) != 1 || !strings.HasPrefix(skipped[0], "00_Inbox/notes/scan.bin: ") {
+		t.Errorf("skipped = %q, want the binary file with its error", skipped)
+	}
+
+	// Incorporated files are pending again only once they change
+	s.Incorporated["00_Inbox/notes/kickoff.md"] = pending[0].Hash
+	if pending, _, _ = Pending(m, layout, s); len(pending) != 0 {
+		t.Errorf("incorporated file still pending: %+v", pending)
+	}
+	writeFile(t, m, "00_Inbox/notes/kickoff.md", "Kickoff notes, revised")
+	if pending, _, _ = Pending(m, layout, s); len(pending) != 1 {
+		t.Errorf("changed file not pending: %+v", pending)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	m, layout := newProject(t)
+	p := &provider.FakeProvider{}
+	writeFile(t, m, "00_Inbox/notes/kickoff.md", "Kickoff notes")
+
+	s, err := LoadState(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, _, err := Pending(m, layout, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Update(p, m, layout, s, pending, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Version != 1 || result.Previous != "" || result.DiffFile != "" {
+		t.Errorf("first update = %+v, want version 1 without a previous version", result)
+	}
+	first, err := os.ReadFile(m.Path(File(layout)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(first), "### 00_Inbox/notes/kickoff.md") {
+		t.Errorf("overview does not include the material:\n%s", first)
+	}
+
+	// The state is saved with the hash of each incorporated file
+	saved, err := LoadState(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Version != 1 || saved.Model != "fake" ||
+		saved.Incorporated["00_Inbox/notes/kickoff.md"] != provenance.Hash([]byte("Kickoff notes")) {
+		t.Errorf("saved state = %+v", saved)
+	}
+
+	writeFile(t, m, "00_Inbox/notes/pricing.md", "Pricing call")
+	pending, _, err = Pending(m, layout, saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].File != "00_Inbox/notes/pricing.md" {
+		t.Fatalf("pending = %+v, want only the new file", pending)
+	}
+	result, err = Update(p, m, layout, saved, pending, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Version != 2 ||
+		result.Previous != "99_Assets/Project_Overview/versions/Project_Overview_v1.md" ||
+		result.DiffFile != "99_Assets/Project_Overview/versions/Project_Overview_v2.diff" {
+		t.Errorf("second update = %+v", result)
+	}
+	previous, err := os.ReadFile(m.Path(result.Previous))
+	if err != nil || string(previous) != string(first) {
+		t.Errorf("previous version = %q, %v, want the first overview", previous, err)
+	}
+	changes, err := os.ReadFile(m.Path(result.DiffFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(changes) != result.Diff || !strings.Contains(result.Diff, "+### 00_Inbox/notes/pricing.md") {
+		t.Errorf("diff = %q", changes)
+	}
+}
+
+func TestUpdateDefersMaterialOverTheCap(t *testing.T) {
+	m, layout := newProject(t)
+	s := &State{Incorporated: map[string]string{}}
+	big := strings.Repeat("x", MaxMaterial*2/3)
+	pending := []Material{
+		{File: "00_Inbox/notes/a.md", Hash: "a", Content: big},
+		{File: "00_Inbox/notes/b.md", Hash: "b", Content: big},
+		{File: "00_Inbox/notes/c.md", Hash: "c", Content: "small"},
+	}
+
+	result, err := Update(&provider.FakeProvider{}, m, layout, s, pending, "Custom prompt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(result.Incorporated, " ") != "00_Inbox/notes/a.md 00_Inbox/notes/c.md" ||
+		strings.Join(result.Deferred, " ") != "00_Inbox/notes/b.md" {
+		t.Errorf("incorporated %q, deferred %q", result.Incorporated, result.Deferred)
+	}
+	if _, ok := s.Incorporated["00_Inbox/notes/b.md"]; ok {
+		t.Error("deferred material recorded as incorporated")
+	}
+	content, err := os.ReadFile(m.Path(File(layout)))
+	if err != nil || !strings.HasPrefix(string(content), "Custom prompt\n") {
+		t.Errorf("overview = %.40q, %v, want the custom prompt used", content, err)
+	}
+
+	// A single file over the cap is still sent rather than deferred forever
+	huge := []Material{{File: "00_Inbox/notes/huge.md", Hash: "h", Content: big + big}}
+	if result, err = Update(&provider.FakeProvider{}, m, layout, s, huge, ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Incorporated) != 1 || len(result.Deferred) != 0 {
+		t.Errorf("oversized file: incorporated %q, deferred %q", result.Incorporated, result.Deferred)
+	}
+}
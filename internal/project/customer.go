@@ -0,0 +1,226 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomerProfileFile is the profile written into each customer folder
+const CustomerProfileFile = "profile.yaml"
+
+// CustomerProfile describes a customer, stored in its folder
+type CustomerProfile struct {
+	Name        string    `yaml:"name"`
+	Industry    string    `yaml:"industry"`
+	Size        string    `yaml:"size"`
+	InstanceURL string    `yaml:"instance_url"`
+	Contacts    []Contact `yaml:"contacts"`
+}
+
+// Contact is a key person at a customer
+type Contact struct {
+	Name  string `yaml:"name"`
+	Role  string `yaml:"role"`
+	Email string `yaml:"email"`
+	Phone string `yaml:"phone"`
+}
+
+// Slug turns a customer name into a safe folder name, e.g. "Acme/EMEA"
+// becomes "Acme_EMEA"
+func Slug(name string) (string, error) {
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '.':
+			b.WriteRune(r)
+			lastUnderscore = false
+		case !lastUnderscore:
+			b.WriteRune('_')
+			lastUnderscore = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "_.-")
+	if slug == "" {
+		return "", fmt.Errorf("customer name %q has no usable characters", name)
+	}
+	return slug, nil
+}
+
+// ValidateCustomerDir checks that a customer folder is a single folder name,
+// so it always resolves inside the customers directory
+func ValidateCustomerDir(dir string) error {
+	if dir == "" || dir == "." || dir == ".." || strings.ContainsAny(dir, `/\`) || filepath.VolumeName(dir) != "" {
+		return fmt.Errorf("customer folder %q is not a single folder name", dir)
+	}
+	return nil
+}
+
+// CustomerPath returns the folder of a customer
+func (m *Manifest) CustomerPath(layout *Layout, c Customer) string {
+	return m.Path(layout.CustomersDir(), c.Dir)
+}
+
+// FindCustomer returns the index of a customer by name or folder, ignoring
+// case, or -1
+func (m *Manifest) FindCustomer(ref string) int {
+	for i, c := range m.Customers {
+		if strings.EqualFold(c.Name, ref) || strings.EqualFold(c.Dir, ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddCustomer registers a customer, creating its folder and seeding its
+// profile
+func (m *Manifest) AddCustomer(layout *Layout, profile CustomerProfile) (Customer, error) {
+	dir, err := Slug(profile.Name)
+	if err != nil {
+		return Customer{}, err
+	}
+	if m.FindCustomer(profile.Name) >= 0 || m.FindCustomer(dir) >= 0 {
+		return Customer{}, fmt.Errorf("customer %q already exists", profile.Name)
+	}
+
+	customer := Customer{Name: strings.TrimSpace(profile.Name), Dir: dir}
+	profile.Name = customer.Name
+	path := m.CustomerPath(layout, customer)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return Customer{}, fmt.Errorf("failed to create customer directory: %w", err)
+	}
+	if err := SeedCustomerProfile(path, profile); err != nil {
+		return Customer{}, err
+	}
+
+	m.Customers = append(m.Customers, customer)
+	return customer, nil
+}
+
+// RenameCustomer renames a customer and moves its folder
+func (m *Manifest) RenameCustomer(layout *Layout, ref, newName string) (Customer, error) {
+	i := m.FindCustomer(ref)
+	if i < 0 {
+		return Customer{}, fmt.Errorf("customer %q not found", ref)
+	}
+
+	dir, err := Slug(newName)
+	if err != nil {
+		return Customer{}, err
+	}
+	if j := m.FindCustomer(dir); j >= 0 && j != i {
+		return Customer{}, fmt.Errorf("customer %q already exists", newName)
+	}
+
+	old := m.Customers[i]
+	if err := ValidateCustomerDir(old.Dir); err != nil {
+		return Customer{}, err
+	}
+	renamed := Customer{Name: strings.TrimSpace(newName), Dir: dir}
+	oldPath, newPath := m.CustomerPath(layout, old), m.CustomerPath(layout, renamed)
+
+	if oldPath != newPath {
+		if _, err := os.Stat(newPath); err == nil && !strings.EqualFold(oldPath, newPath) {
+			return Customer{}, fmt.Errorf("folder %s already exists", newPath)
+		}
+		if err := os.Rename(oldPath, newPath); err != nil {
+			return Customer{}, fmt.Errorf("failed to rename customer folder: %w", err)
+		}
+	}
+
+	if profile, err := LoadCustomerProfile(newPath); err == nil {
+		profile.Name = renamed.Name
+		if err := writeCustomerProfile(newPath, *profile); err != nil {
+			return Customer{}, err
+		}
+	}
+
+	m.Customers[i] = renamed
+	return renamed, nil
+}
+
+// RemoveCustomer unregisters a customer, deleting its folder unless keepFiles
+// is set
+func (m *Manifest) RemoveCustomer(layout *Layout, ref string, keepFiles bool) (Customer, error) {
+	i := m.FindCustomer(ref)
+	if i < 0 {
+		return Customer{}, fmt.Errorf("customer %q not found", ref)
+	}
+
+	customer := m.Customers[i]
+	if !keepFiles {
+		if err := ValidateCustomerDir(customer.Dir); err != nil {
+			return Customer{}, err
+		}
+		if err := os.RemoveAll(m.CustomerPath(layout, customer)); err != nil {
+			return Customer{}, fmt.Errorf("failed to remove customer folder: %w", err)
+		}
+	}
+
+	m.Customers = append(m.Customers[:i], m.Customers[i+1:]...)
+	return customer, nil
+}
+
+// SeedCustomerProfile writes a customer profile unless one already exists
+func SeedCustomerProfile(customerPath string, profile CustomerProfile) error {
+	if _, err := os.Stat(filepath.Join(customerPath, CustomerProfileFile)); err == nil {
+		return nil
+	}
+	return writeCustomerProfile(customerPath, profile)
+}
+
+// CustomerProfileSeed returns the seeded profile file of a customer, relative
+// to the project root
+func CustomerProfileSeed(layout *Layout, customer Customer) (File, error) {
+	content, err := customerProfileContent(CustomerProfile{Name: customer.Name})
+	if err != nil {
+		return File{}, err
+	}
+	path := filepath.ToSlash(filepath.Join(layout.CustomersDir(), customer.Dir, CustomerProfileFile))
+	return File{Path: path, Content: content}, nil
+}
+
+// LoadCustomerProfile reads the profile in a customer folder
+func LoadCustomerProfile(customerPath string) (*CustomerProfile, error) {
+	content, err := os.ReadFile(filepath.Join(customerPath, CustomerProfileFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var profile CustomerProfile
+	if err := yaml.Unmarshal(content, &profile); err != nil {
+		return nil, fmt.Errorf("invalid customer profile in %s: %w", customerPath, err)
+	}
+	return &profile, nil
+}
+
+func customerProfileContent(profile CustomerProfile) ([]byte, error) {
+	if len(profile.Contacts) == 0 {
+		profile.Contacts = []Contact{{}}
+	}
+	content, err := yaml.Marshal(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode customer profile: %w", err)
+	}
+
+	header := "# Customer profile: industry, size, ServiceNow instance and key contacts\n"
+	return append([]byte(header), content...), nil
+}
+
+func writeCustomerProfile(customerPath string, profile CustomerProfile) error {
+	content, err := customerProfileContent(profile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(customerPath, CustomerProfileFile), content, 0644); err != nil {
+		return fmt.Errorf("failed to write customer profile: %w", err)
+	}
+	return nil
+}
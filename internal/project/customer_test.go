@@ -0,0 +1,96 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCustomerDir(t *testing.T) {
+	valid := []string{"Acme", "Acme_EMEA", "acme.corp", "Acme-2"}
+	for _, dir := range valid {
+		if err := ValidateCustomerDir(dir); err != nil {
+			t.Errorf("ValidateCustomerDir(%q) = %v", dir, err)
+		}
+	}
+	invalid := []string{"", ".", "..", "../..", "a/b", `a\b`, "/etc", "../Acme"}
+	for _, dir := range invalid {
+		if err := ValidateCustomerDir(dir); err == nil {
+			t.Errorf("ValidateCustomerDir(%q) accepted", dir)
+		}
+	}
+}
+
+func writeManifest(t *testing.T, root, customers string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, MetaDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "project: Test\ncustomers:\n" + customers
+	if err := os.WriteFile(ManifestPath(root), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadManifestRejectsUnsafeCustomerDirs(t *testing.T) {
+	for _, dir := range []string{`""`, `".."`, `"../.."`, `"a/b"`} {
+		root := t.TempDir()
+		writeManifest(t, root, "  - name: Acme\n    dir: "+dir+"\n")
+		if _, err := LoadManifest(root); err == nil || !strings.Contains(err.Error(), "Acme") {
+			t.Errorf("dir %s: LoadManifest error = %v", dir, err)
+		}
+	}
+
+	root := t.TempDir()
+	writeManifest(t, root, "  - name: Acme\n    dir: Acme\n")
+	m, err := LoadManifest(root)
+	if err != nil {
+		t.Fatalf("LoadManifest() = %v", err)
+	}
+	if len(m.Customers) != 1 || m.Customers[0].Dir != "Acme" {
+		t.Errorf("Customers = %+v", m.Customers)
+	}
+}
+
+func TestRemoveCustomerRefusesUnsafeDir(t *testing.T) {
+	root := t.TempDir()
+	layout := DefaultLayout()
+	customers := filepath.Join(root, layout.CustomersDir())
+	if err := os.MkdirAll(filepath.Join(customers, "Other"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManifest(root, "Test")
+	m.Customers = []Customer{{Name: "Broken", Dir: ""}, {Name: "Escape", Dir: ".."}}
+	for _, ref := range []string{"Broken", "Escape"} {
+		if _, err := m.RemoveCustomer(layout, ref, false); err == nil {
+			t.Errorf("RemoveCustomer(%q) accepted an unsafe folder", ref)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(customers, "Other")); err != nil {
+		t.Errorf("customers directory was touched: %v", err)
+	}
+}
+
+func TestRemoveCustomer(t *testing.T) {
+	root := t.TempDir()
+	layout := DefaultLayout()
+	m := NewManifest(root, "Test")
+	c, err := m.AddCustomer(layout, CustomerProfile{Name: "Acme Corp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Dir != "Acme_Corp" {
+		t.Errorf("Dir = %q", c.Dir)
+	}
+	if _, err := m.RemoveCustomer(layout, "acme corp", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(m.CustomerPath(layout, c)); !os.IsNotExist(err) {
+		t.Errorf("customer folder still exists: %v", err)
+	}
+	if len(m.Customers) != 0 {
+		t.Errorf("Customers = %+v", m.Customers)
+	}
+}
@@ -0,0 +1,325 @@
+package project
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// LayoutVersion is the newest layout schema version this CLI understands
+const LayoutVersion = 1
+
+// BuiltinSource names layouts and profiles compiled into the binary
+const BuiltinSource = "built-in"
+
+// layoutFiles are the override filenames looked up in each config directory
+var layoutFiles = []string{"layout.yaml", "layout.yml", "layout.json"}
+
+// RequiredDirectories are the folders the CLI itself writes to, which every
+// layout must declare at the top level
+var RequiredDirectories = []string{"10_PromptTemplates"}
+
+// Roles mark the folders the CLI writes its own outputs to, so a layout can
+// rename or move them
+const (
+	RoleAssets                 = "assets"
+	RoleOverview               = "overview"
+	RoleCommunications         = "communications"
+	RoleDocuments              = "documents"
+	RoleSummaries              = "summaries"
+	RoleNotes                  = "notes"
+	RoleCalls                  = "calls"
+	RoleEmails                 = "emails"
+	RoleDecks                  = "decks"
+	RoleCommunicationTemplates = "communication-templates"
+)
+
+// roles are the roles a layout directory may take
+var roles = []string{RoleAssets, RoleOverview, RoleCommunications, RoleDocuments, RoleSummaries,
+	RoleNotes, RoleCalls, RoleEmails, RoleDecks, RoleCommunicationTemplates}
+
+//go:embed layouts/default.yaml
+var defaultLayout []byte
+
+// Layout describes the directory tree of a presales project
+type Layout struct {
+	Version     int         `yaml:"version" json:"version"`
+	Directories []Directory `yaml:"directories" json:"directories"`
+
+	// Source is the file the layout was loaded from
+	Source string `yaml:"-" json:"-"`
+}
+
+// Directory is a folder in the project layout
+type Directory struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Customers marks the folder that holds one sub-folder per customer
+	Customers bool `yaml:"customers,omitempty" json:"customers,omitempty"`
+	// Inbox marks the folder raw material (calls, emails, notes) lands in
+	Inbox bool `yaml:"inbox,omitempty" json:"inbox,omitempty"`
+	// Role marks a folder the CLI writes to, such as the project overview
+	Role     string      `yaml:"role,omitempty" json:"role,omitempty"`
+	Children []Directory `yaml:"children,omitempty" json:"children,omitempty"`
+}
+
+// DefaultLayout returns the layout compiled into the binary
+func DefaultLayout() *Layout {
+	layout, err := ParseLayout(defaultLayout)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in layout: %v", err))
+	}
+	layout.Source = BuiltinSource
+	return layout
+}
+
+// LoadLayout returns the effective layout: a user override, else an
+// organisation override, else the built-in default
+func LoadLayout() (*Layout, error) {
+	for _, dir := range config.OverrideDirs() {
+		for _, name := range layoutFiles {
+			path := filepath.Join(dir, name)
+			content, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read layout %s: %w", path, err)
+			}
+
+			layout, err := ParseLayout(content)
+			if err != nil {
+				return nil, fmt.Errorf("invalid layout %s: %w", path, err)
+			}
+			layout.Source = path
+			return layout, nil
+		}
+	}
+
+	return DefaultLayout(), nil
+}
+
+// ParseLayout decodes and validates a YAML or JSON layout
+func ParseLayout(content []byte) (*Layout, error) {
+	var layout Layout
+	if err := yaml.Unmarshal(content, &layout); err != nil {
+		return nil, err
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// Validate checks the layout version and directory names
+func (l *Layout) Validate() error {
+	if l.Version < 1 {
+		return fmt.Errorf("layout version is required")
+	}
+	if l.Version > LayoutVersion {
+		return fmt.Errorf("layout version %d requires a newer now-sc (supports up to %d)", l.Version, LayoutVersion)
+	}
+
+	customerDirs := 0
+	roleDirs := map[string]string{}
+	var check func(dirs []Directory, path string) error
+	check = func(dirs []Directory, path string) error {
+		seen := map[string]bool{}
+		for _, dir := range dirs {
+			if dir.Name == "" || dir.Name == "." || dir.Name == ".." || strings.ContainsAny(dir.Name, `/\`) {
+				return fmt.Errorf("invalid directory name %q in %s", dir.Name, path)
+			}
+			if seen[dir.Name] {
+				return fmt.Errorf("duplicate directory %q in %s", dir.Name, path)
+			}
+			seen[dir.Name] = true
+
+			if dir.Customers {
+				customerDirs++
+			}
+			if dir.Role != "" {
+				if !slices.Contains(roles, dir.Role) {
+					return fmt.Errorf("unknown role %q for %s%s (expected one of %s)", dir.Role, path, dir.Name, strings.Join(roles, ", "))
+				}
+				if other, ok := roleDirs[dir.Role]; ok {
+					return fmt.Errorf("role %q is given to both %s and %s%s", dir.Role, other, path, dir.Name)
+				}
+				roleDirs[dir.Role] = path + dir.Name
+			}
+			if err := check(dir.Children, path+dir.Name+"/"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(l.Directories, "/"); err != nil {
+		return err
+	}
+
+	if customerDirs != 1 {
+		return fmt.Errorf("layout must mark exactly one directory with customers: true")
+	}
+	for _, required := range RequiredDirectories {
+		if l.find(required) == nil {
+			return fmt.Errorf("layout must include the %s directory", required)
+		}
+	}
+	return nil
+}
+
+// CustomersDir returns the path, relative to the project root, of the folder
+// holding customer folders
+func (l *Layout) CustomersDir() string {
+	return l.markedDir(func(dir Directory) bool { return dir.Customers })
+}
+
+// InboxDir returns the path, relative to the project root, of the inbox
+// folder. Layouts recorded before the inbox marker existed fall back to a
+// top-level folder named like "00_Inbox".
+func (l *Layout) InboxDir() string {
+	if dir := l.markedDir(func(dir Directory) bool { return dir.Inbox }); dir != "" {
+		return dir
+	}
+	for _, dir := range l.Directories {
+		if strings.HasSuffix(strings.ToLower(dir.Name), "inbox") {
+			return dir.Name
+		}
+	}
+	return ""
+}
+
+// RoleDir returns the path, relative to the project root, of the folder with
+// the given role. Layouts that do not mark the role, such as those recorded
+// before roles existed, fall back to the built-in layout's folder, kept
+// inside this layout's inbox for inbox folders.
+func (l *Layout) RoleDir(role string) string {
+	if dir := l.markedDir(func(dir Directory) bool { return dir.Role == role }); dir != "" {
+		return dir
+	}
+	if l.Source == BuiltinSource {
+		return ""
+	}
+
+	builtin := DefaultLayout()
+	dir := builtin.RoleDir(role)
+	if rel, err := filepath.Rel(builtin.InboxDir(), dir); err == nil && !strings.HasPrefix(rel, "..") {
+		if inbox := l.InboxDir(); inbox != "" {
+			return filepath.Join(inbox, rel)
+		}
+	}
+	return dir
+}
+
+func (l *Layout) markedDir(marked func(Directory) bool) string {
+	var search func(dirs []Directory, prefix string) string
+	search = func(dirs []Directory, prefix string) string {
+		for _, dir := range dirs {
+			path := filepath.Join(prefix, dir.Name)
+			if marked(dir) {
+				return path
+			}
+			if found := search(dir.Children, path); found != "" {
+				return found
+			}
+		}
+		return ""
+	}
+	return search(l.Directories, "")
+}
+
+// Paths returns every directory in the layout, relative to the project root,
+// including a folder for each given customer
+func (l *Layout) Paths(customers ...string) []string {
+	var paths []string
+	var walk func(dirs []Directory, prefix string)
+	walk = func(dirs []Directory, prefix string) {
+		for _, dir := range dirs {
+			path := filepath.Join(prefix, dir.Name)
+			paths = append(paths, path)
+			if dir.Customers {
+				for _, customer := range customers {
+					paths = append(paths, filepath.Join(path, customer))
+				}
+			}
+			walk(dir.Children, path)
+		}
+	}
+	walk(l.Directories, "")
+	return paths
+}
+
+// Tree renders the layout as a box-drawing tree, as printed by init
+func (l *Layout) Tree(customers ...string) []string {
+	var lines []string
+	var walk func(dirs []Directory, indent string)
+	walk = func(dirs []Directory, indent string) {
+		for i, dir := range dirs {
+			children := dir.Children
+			if dir.Customers {
+				var customerDirs []Directory
+				for _, customer := range customers {
+					customerDirs = append(customerDirs, Directory{Name: customer})
+				}
+				children = append(customerDirs, children...)
+			}
+
+			branch, next := "├── ", "│   "
+			if i == len(dirs)-1 {
+				branch, next = "└── ", "    "
+			}
+			lines = append(lines, indent+branch+dir.Name+"/")
+			walk(children, indent+next)
+		}
+	}
+	walk(l.Directories, "")
+	return lines
+}
+
+// Markdown renders the layout as a nested Markdown list with descriptions, as
+// used in the project README
+func (l *Layout) Markdown(customers ...string) string {
+	var b strings.Builder
+	var walk func(dirs []Directory, depth int)
+	walk = func(dirs []Directory, depth int) {
+		for _, dir := range dirs {
+			indent := strings.Repeat("  ", depth)
+			if depth == 0 {
+				fmt.Fprintf(&b, "%s- **%s/**", indent, dir.Name)
+			} else {
+				fmt.Fprintf(&b, "%s- %s/", indent, dir.Name)
+			}
+			if dir.Description != "" {
+				fmt.Fprintf(&b, " - %s", dir.Description)
+			}
+			b.WriteString("\n")
+
+			if dir.Customers {
+				for _, customer := range customers {
+					fmt.Fprintf(&b, "%s  - %s/\n", indent, customer)
+				}
+			}
+			walk(dir.Children, depth+1)
+
+			if depth == 0 {
+				b.WriteString("\n")
+			}
+		}
+	}
+	walk(l.Directories, 0)
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func (l *Layout) find(name string) *Directory {
+	for i := range l.Directories {
+		if l.Directories[i].Name == name {
+			return &l.Directories[i]
+		}
+	}
+	return nil
+}
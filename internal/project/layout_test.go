@@ -0,0 +1,73 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoleDir(t *testing.T) {
+	layout := DefaultLayout()
+	want := map[string]string{
+		RoleAssets:                 "99_Assets",
+		RoleOverview:               "99_Assets/Project_Overview",
+		RoleSummaries:              "99_Assets/Summaries",
+		RoleNotes:                  "00_Inbox/notes",
+		RoleCommunicationTemplates: "30_CommunicationTemplates",
+	}
+	for role, dir := range want {
+		if got := layout.RoleDir(role); got != dir {
+			t.Errorf("RoleDir(%q) = %q, want %q", role, got, dir)
+		}
+	}
+
+	renamed, err := ParseLayout([]byte(`version: 1
+directories:
+  - name: Customers
+    customers: true
+  - name: 10_PromptTemplates
+  - name: Outputs
+    role: assets
+    children:
+      - name: Overview
+        role: overview
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := renamed.RoleDir(RoleOverview); got != "Outputs/Overview" {
+		t.Errorf("renamed overview = %q", got)
+	}
+	// Unmarked roles, as in layouts recorded before roles existed, use the
+	// built-in folder
+	if got := renamed.RoleDir(RoleSummaries); got != "99_Assets/Summaries" {
+		t.Errorf("unmarked summaries = %q", got)
+	}
+
+	inbox, err := ParseLayout([]byte(`version: 1
+directories:
+  - name: Inbox
+    inbox: true
+  - name: Customers
+    customers: true
+  - name: 10_PromptTemplates
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := inbox.RoleDir(RoleDecks); got != "Inbox/decks" {
+		t.Errorf("unmarked decks = %q, want it inside the layout's inbox", got)
+	}
+}
+
+func TestValidateRoles(t *testing.T) {
+	base := "version: 1\ndirectories:\n  - name: C\n    customers: true\n  - name: 10_PromptTemplates\n"
+	tests := map[string]string{
+		"unknown role": "  - name: A\n    role: archive\n",
+		"is given to":  "  - name: A\n    role: assets\n  - name: B\n    role: assets\n",
+	}
+	for want, dirs := range tests {
+		if _, err := ParseLayout([]byte(base + dirs)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseLayout(%q) = %v, want %q", dirs, err, want)
+		}
+	}
+}
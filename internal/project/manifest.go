@@ -0,0 +1,205 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MetaDir holds now-sc metadata inside a project
+	MetaDir = ".now-sc"
+	// ManifestFile is the project manifest inside MetaDir
+	ManifestFile = "project.yaml"
+	// LayoutSnapshotFile records the layout a project was created with
+	LayoutSnapshotFile = "layout.yaml"
+)
+
+// ErrNotProject is returned when no manifest is found
+var ErrNotProject = errors.New("not inside a now-sc project")
+
+// Manifest records what created a project and its current state
+type Manifest struct {
+	Project       string     `yaml:"project"`
+	Customers     []Customer `yaml:"customers"`
+	Owner         string     `yaml:"owner,omitempty"`
+	Stage         string     `yaml:"stage,omitempty"`
+	Profile       string     `yaml:"profile,omitempty"`
+	Created       time.Time  `yaml:"created"`
+	Updated       time.Time  `yaml:"updated"`
+	CLIVersion    string     `yaml:"cli_version"`
+	LayoutVersion int        `yaml:"layout_version"`
+	// Prompts maps installed base prompt filenames to their upstream blob SHA
+	Prompts map[string]string `yaml:"prompts,omitempty"`
+
+	root string
+}
+
+// Customer is a customer folder in the project
+type Customer struct {
+	Name string `yaml:"name"`
+	// Dir is the folder name inside the customers directory
+	Dir string `yaml:"dir"`
+}
+
+// NewManifest creates a manifest for a new project rooted at root
+func NewManifest(root, projectName string) *Manifest {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &Manifest{
+		Project:       projectName,
+		Created:       now,
+		Updated:       now,
+		CLIVersion:    version.Version,
+		LayoutVersion: LayoutVersion,
+		root:          root,
+	}
+}
+
+// ManifestPath returns the manifest location for a project root
+func ManifestPath(root string) string {
+	return filepath.Join(root, MetaDir, ManifestFile)
+}
+
+// FindRoot walks up from start until it finds a directory with a manifest
+func FindRoot(start string) (string, error) {
+	abs, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+
+	dir := abs
+	for {
+		if _, err := os.Stat(ManifestPath(dir)); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%w: no %s found in %s or any parent directory", ErrNotProject, filepath.Join(MetaDir, ManifestFile), abs)
+		}
+		dir = parent
+	}
+}
+
+// LoadManifest reads the manifest of the project rooted at root
+func LoadManifest(root string) (*Manifest, error) {
+	content, err := os.ReadFile(ManifestPath(root))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s has no %s", ErrNotProject, root, filepath.Join(MetaDir, ManifestFile))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", ManifestPath(root), err)
+	}
+	for _, c := range m.Customers {
+		if err := ValidateCustomerDir(c.Dir); err != nil {
+			return nil, fmt.Errorf("invalid manifest %s: customer %q: %w", ManifestPath(root), c.Name, err)
+		}
+	}
+	m.root = root
+	return &m, nil
+}
+
+// Find locates and loads the manifest of the project containing start
+func Find(start string) (*Manifest, error) {
+	root, err := FindRoot(start)
+	if err != nil {
+		return nil, err
+	}
+	return LoadManifest(root)
+}
+
+// Root returns the project root directory
+func (m *Manifest) Root() string {
+	return m.root
+}
+
+// Path joins path elements onto the project root
+func (m *Manifest) Path(elem ...string) string {
+	return filepath.Join(append([]string{m.root}, elem...)...)
+}
+
+// CustomerNames returns the display names of the project's customers
+func (m *Manifest) CustomerNames() []string {
+	names := make([]string, len(m.Customers))
+	for i, c := range m.Customers {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Save writes the manifest, stamping the update time and CLI version
+func (m *Manifest) Save() error {
+	m.Updated = time.Now().UTC().Truncate(time.Second)
+	m.CLIVersion = version.Version
+
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(m.Path(MetaDir), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", MetaDir, err)
+	}
+	if err := os.WriteFile(ManifestPath(m.root), content, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// SaveLayoutSnapshot records the layout the project was created with
+func (m *Manifest) SaveLayoutSnapshot(layout *Layout) error {
+	content, err := yaml.Marshal(layout)
+	if err != nil {
+		return fmt.Errorf("failed to encode layout: %w", err)
+	}
+	if err := os.MkdirAll(m.Path(MetaDir), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", MetaDir, err)
+	}
+	return os.WriteFile(m.Path(MetaDir, LayoutSnapshotFile), content, 0644)
+}
+
+// LoadLayout returns the layout recorded for the project, falling back to the
+// effective layout when no snapshot exists
+func (m *Manifest) LoadLayout() (*Layout, error) {
+	path := m.Path(MetaDir, LayoutSnapshotFile)
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return LoadLayout()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout snapshot: %w", err)
+	}
+
+	layout, err := ParseLayout(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid layout snapshot %s: %w", path, err)
+	}
+	layout.Source = path
+	return layout, nil
+}
+
+// DefaultOwner returns the git user name, falling back to the login name
+func DefaultOwner() string {
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return ""
+}
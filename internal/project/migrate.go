@@ -0,0 +1,455 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Migration step actions
+const (
+	StepMkdir    = "mkdir"
+	StepMove     = "move"
+	StepCustomer = "customer"
+)
+
+// MigrationStep is a single change made by a migration. Paths are relative to
+// the project root, with forward slashes.
+type MigrationStep struct {
+	Action string
+	Path   string
+	// To is the destination of a move
+	To string
+	// Customer is set when the step renames or registers a customer folder
+	Customer *Customer
+}
+
+// Describe returns a one-line description of the step
+func (s MigrationStep) Describe() string {
+	switch s.Action {
+	case StepMove:
+		return fmt.Sprintf("move   %s/ -> %s/", s.Path, s.To)
+	case StepCustomer:
+		return fmt.Sprintf("register customer %s (%s/)", s.Customer.Name, s.Path)
+	}
+	return fmt.Sprintf("mkdir  %s/", s.Path)
+}
+
+// Migration upgrades a project to a layout version
+type Migration struct {
+	Version     int
+	Description string
+	// Plan returns the steps needed on the project at root. The manifest is
+	// nil for projects created before manifests existed.
+	Plan func(root string, m *Manifest) ([]MigrationStep, error)
+}
+
+// migrations are applied in order to projects below their version
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "Adopt projects created before the project manifest: add missing standard folders, give customer folders safe names and record the manifest",
+		Plan:        planAdoptLegacy,
+	},
+}
+
+// PlannedMigration is a migration with the steps it will take on a project
+type PlannedMigration struct {
+	Migration
+	Steps []MigrationStep
+}
+
+// MigrationPlan brings a project from its recorded layout version to the
+// current one
+type MigrationPlan struct {
+	Root       string
+	From       int
+	To         int
+	Migrations []PlannedMigration
+	// Links maps project files to the number of internal links the moves
+	// will rewrite in them
+	Links map[string]int
+
+	manifest *Manifest
+}
+
+// Legacy reports whether the project has no manifest yet
+func (p *MigrationPlan) Legacy() bool {
+	return p.manifest == nil
+}
+
+// UpToDate reports whether there is nothing to migrate
+func (p *MigrationPlan) UpToDate() bool {
+	return len(p.Migrations) == 0
+}
+
+// FindMigrationRoot locates the project containing start, including projects
+// created before manifests existed, which are recognised by their required
+// folders
+func FindMigrationRoot(start string) (string, error) {
+	if root, err := FindRoot(start); err == nil {
+		return root, nil
+	}
+	return FindLegacyRoot(start)
+}
+
+// FindLegacyRoot walks up from start looking for a project without a manifest
+func FindLegacyRoot(start string) (string, error) {
+	abs, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+
+	for dir := abs; ; {
+		if isLegacyRoot(dir) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%w: no %s found in %s or its parents", ErrNotProject, MetaDir, abs)
+		}
+		dir = parent
+	}
+}
+
+func isLegacyRoot(dir string) bool {
+	for _, required := range RequiredDirectories {
+		if info, err := os.Stat(filepath.Join(dir, required)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyBackup checks that dir, extracted from a migration backup, is a
+// backup of the project at root: the same folder and, when both have a
+// manifest, the same project. Backups of projects from before the manifest
+// must have the legacy layout.
+func VerifyBackup(dir, root string) error {
+	if filepath.Base(dir) != filepath.Base(root) {
+		return fmt.Errorf("the backup is of %s, not %s", filepath.Base(dir), filepath.Base(root))
+	}
+	saved, err := LoadManifest(dir)
+	if errors.Is(err, ErrNotProject) {
+		if isLegacyRoot(dir) {
+			return nil
+		}
+		return fmt.Errorf("the backup does not contain a now-sc project")
+	}
+	if err != nil {
+		return err
+	}
+	if current, err := LoadManifest(root); err == nil && current.Project != saved.Project {
+		return fmt.Errorf("the backup is of project %q, not %q", saved.Project, current.Project)
+	}
+	return nil
+}
+
+// PlanMigration works out the migrations needed by the project at root
+func PlanMigration(root string) (*MigrationPlan, error) {
+	plan := &MigrationPlan{Root: root, To: LayoutVersion, Links: map[string]int{}}
+
+	if _, err := os.Stat(ManifestPath(root)); err == nil {
+		m, err := LoadManifest(root)
+		if err != nil {
+			return nil, err
+		}
+		plan.manifest = m
+		plan.From = m.LayoutVersion
+	} else if !isLegacyRoot(root) {
+		return nil, fmt.Errorf("%w: %s", ErrNotProject, root)
+	}
+
+	if plan.From > LayoutVersion {
+		return nil, fmt.Errorf("project layout version %d is newer than this CLI supports (%d); upgrade now-sc", plan.From, LayoutVersion)
+	}
+
+	moves := map[string]string{}
+	for _, migration := range migrations {
+		if migration.Version <= plan.From {
+			continue
+		}
+		steps, err := migration.Plan(root, plan.manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan migration to version %d: %w", migration.Version, err)
+		}
+		for _, step := range steps {
+			if step.Action == StepMove {
+				moves[step.Path] = step.To
+			}
+		}
+		plan.Migrations = append(plan.Migrations, PlannedMigration{Migration: migration, Steps: steps})
+	}
+
+	if len(moves) > 0 {
+		links, err := rewriteLinks(root, moves, true)
+		if err != nil {
+			return nil, err
+		}
+		plan.Links = links
+	}
+	return plan, nil
+}
+
+// Apply runs the planned steps, rewrites internal links and records the new
+// layout version in the manifest, creating it for legacy projects
+func (p *MigrationPlan) Apply() (*Manifest, error) {
+	m := p.manifest
+	if m == nil {
+		m = NewManifest(p.Root, filepath.Base(p.Root))
+		m.Owner = DefaultOwner()
+		m.Profile = DefaultProfile
+	}
+
+	// Links are rewritten before moving so they resolve from where each file
+	// was written
+	moves := map[string]string{}
+	for _, migration := range p.Migrations {
+		for _, step := range migration.Steps {
+			if step.Action == StepMove {
+				moves[step.Path] = step.To
+			}
+		}
+	}
+	if len(moves) > 0 {
+		if _, err := rewriteLinks(p.Root, moves, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, migration := range p.Migrations {
+		for _, step := range migration.Steps {
+			source := filepath.Join(p.Root, filepath.FromSlash(step.Path))
+			switch step.Action {
+			case StepMkdir:
+				if err := os.MkdirAll(source, 0755); err != nil {
+					return nil, fmt.Errorf("failed to create %s: %w", step.Path, err)
+				}
+			case StepMove:
+				target := filepath.Join(p.Root, filepath.FromSlash(step.To))
+				if _, err := os.Stat(target); err == nil {
+					return nil, fmt.Errorf("cannot move %s: %s already exists", step.Path, step.To)
+				}
+				if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+					return nil, fmt.Errorf("failed to create %s: %w", path.Dir(step.To), err)
+				}
+				if err := os.Rename(source, target); err != nil {
+					return nil, fmt.Errorf("failed to move %s: %w", step.Path, err)
+				}
+			}
+
+			if step.Customer != nil {
+				if i := m.FindCustomer(step.Customer.Name); i >= 0 {
+					m.Customers[i] = *step.Customer
+				} else {
+					m.Customers = append(m.Customers, *step.Customer)
+				}
+			}
+		}
+	}
+
+	m.LayoutVersion = p.To
+	if p.manifest == nil {
+		if err := m.SaveLayoutSnapshot(DefaultLayout()); err != nil {
+			return nil, fmt.Errorf("failed to record project layout: %w", err)
+		}
+	}
+	if err := m.Save(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// planAdoptLegacy creates the standard folders missing from projects made by
+// older versions (e.g. 30_CommunicationTemplates), renames customer folders
+// to their safe names and registers them as customers
+func planAdoptLegacy(root string, m *Manifest) ([]MigrationStep, error) {
+	layout := DefaultLayout()
+	var steps []MigrationStep
+
+	for _, dir := range MissingDirectories(root, layout, "") {
+		steps = append(steps, MigrationStep{Action: StepMkdir, Path: filepath.ToSlash(dir)})
+	}
+
+	customersDir := filepath.ToSlash(layout.CustomersDir())
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(customersDir)))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	taken := map[string]bool{}
+	for _, entry := range entries {
+		taken[entry.Name()] = true
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		name := entry.Name()
+		if m != nil {
+			if i := m.FindCustomer(name); i >= 0 {
+				name = m.Customers[i].Name
+			}
+		}
+		dir, err := Slug(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		customer := &Customer{Name: name, Dir: dir}
+		if dir == entry.Name() {
+			if m == nil || m.FindCustomer(dir) < 0 {
+				steps = append(steps, MigrationStep{Action: StepCustomer, Path: path.Join(customersDir, dir), Customer: customer})
+			}
+			continue
+		}
+		if taken[dir] {
+			return nil, fmt.Errorf("cannot rename customer folder %q to %q: the folder already exists", entry.Name(), dir)
+		}
+		taken[dir] = true
+		steps = append(steps, MigrationStep{
+			Action:   StepMove,
+			Path:     path.Join(customersDir, entry.Name()),
+			To:       path.Join(customersDir, dir),
+			Customer: customer,
+		})
+	}
+	return steps, nil
+}
+
+var markdownLink = regexp.MustCompile(`\]\(([^()\s]+|<[^>]+>)((?:\s+"[^"]*")?)\)`)
+
+// rewriteLinks updates relative Markdown links that point into moved paths and
+// returns the number of links changed per file. With dryRun nothing is
+// written.
+func rewriteLinks(root string, moves map[string]string, dryRun bool) (map[string]int, error) {
+	changed := map[string]int{}
+	err := filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); file != root && (name == ".git" || name == MetaDir || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(file), ".md") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		// Files inside a moved folder are found at their new location after
+		// the move, so resolve their links from there
+		current := movedPath(rel, moves)
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		updated := markdownLink.ReplaceAllStringFunc(string(content), func(match string) string {
+			parts := markdownLink.FindStringSubmatch(match)
+			target, ok := rewriteTarget(parts[1], path.Dir(rel), path.Dir(current), moves)
+			if !ok {
+				return match
+			}
+			count++
+			return "](" + target + parts[2] + ")"
+		})
+		if count == 0 {
+			return nil
+		}
+
+		changed[current] = count
+		if dryRun {
+			return nil
+		}
+		return os.WriteFile(file, []byte(updated), 0644)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite links: %w", err)
+	}
+	return changed, nil
+}
+
+// rewriteTarget rewrites a link target written in fromDir so that it points at
+// the moved location from newDir
+func rewriteTarget(target, fromDir, newDir string, moves map[string]string) (string, bool) {
+	angle := strings.HasPrefix(target, "<")
+	raw := strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+	if strings.Contains(raw, "://") || strings.HasPrefix(raw, "#") || strings.HasPrefix(raw, "mailto:") {
+		return "", false
+	}
+
+	fragment := ""
+	if i := strings.IndexAny(raw, "#?"); i >= 0 {
+		raw, fragment = raw[:i], raw[i:]
+	}
+	escaped := strings.Contains(raw, "%")
+	decoded, err := url.PathUnescape(raw)
+	if err != nil || decoded == "" {
+		return "", false
+	}
+
+	absolute := strings.HasPrefix(decoded, "/")
+	resolved := path.Clean(path.Join(fromDir, decoded))
+	if absolute {
+		resolved = path.Clean(strings.TrimPrefix(decoded, "/"))
+	}
+	if strings.HasPrefix(resolved, "../") {
+		return "", false
+	}
+
+	moved := movedPath(resolved, moves)
+	if moved == resolved && fromDir == newDir {
+		return "", false
+	}
+
+	result := "/" + moved
+	if !absolute {
+		rel, err := filepath.Rel(filepath.FromSlash(newDir), filepath.FromSlash(moved))
+		if err != nil {
+			return "", false
+		}
+		result = filepath.ToSlash(rel)
+	}
+	if result == decoded {
+		return "", false
+	}
+
+	if escaped {
+		result = (&url.URL{Path: result}).EscapedPath()
+	}
+	result += fragment
+	if angle {
+		result = "<" + result + ">"
+	}
+	return result, true
+}
+
+// movedPath returns where a project path ends up after the moves
+func movedPath(p string, moves map[string]string) string {
+	for from, to := range moves {
+		if p == from {
+			return to
+		}
+		if strings.HasPrefix(p, from+"/") {
+			return to + strings.TrimPrefix(p, from)
+		}
+	}
+	return p
+}
@@ -0,0 +1,56 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyBackup(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "acme-poc")
+	writeManifest(t, root, "")
+
+	backups := t.TempDir()
+	same := filepath.Join(backups, "same", "acme-poc")
+	writeManifest(t, same, "")
+	if err := VerifyBackup(same, root); err != nil {
+		t.Errorf("backup of the same project: %v", err)
+	}
+
+	other := filepath.Join(backups, "other", "acme-poc")
+	if err := os.MkdirAll(filepath.Join(other, MetaDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ManifestPath(other), []byte("project: Globex\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyBackup(other, root); err == nil || !strings.Contains(err.Error(), "Globex") {
+		t.Errorf("backup of another project: %v", err)
+	}
+
+	renamed := filepath.Join(backups, "renamed", "globex-poc")
+	writeManifest(t, renamed, "")
+	if err := VerifyBackup(renamed, root); err == nil {
+		t.Error("backup of another folder was accepted")
+	}
+
+	empty := filepath.Join(backups, "empty", "acme-poc")
+	if err := os.MkdirAll(empty, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyBackup(empty, root); err == nil {
+		t.Error("a folder without a project was accepted")
+	}
+
+	legacy := filepath.Join(backups, "legacy", "acme-poc")
+	for _, dir := range RequiredDirectories {
+		if err := os.MkdirAll(filepath.Join(legacy, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := VerifyBackup(legacy, root); err != nil {
+		t.Errorf("backup of a project from before the manifest: %v", err)
+	}
+}
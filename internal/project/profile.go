@@ -0,0 +1,282 @@
+package project
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// ProfilesDir is the folder, inside config directories and template sources,
+// holding shared profiles
+const ProfilesDir = "profiles"
+
+//go:embed profiles/*.yaml
+var builtinProfiles embed.FS
+
+// Profile bundles a layout, a prompt set and seed files for one type of
+// engagement
+type Profile struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Layout replaces the effective layout when set
+	Layout *Layout `yaml:"layout,omitempty"`
+	// Directories are merged into the layout
+	Directories []Directory `yaml:"directories,omitempty"`
+	Prompts     PromptSet   `yaml:"prompts,omitempty"`
+	Files       []SeedFile  `yaml:"files,omitempty"`
+
+	// Source is where the profile was loaded from
+	Source string `yaml:"-"`
+}
+
+// PromptSet selects which base prompts a profile installs. An empty set
+// installs every prompt. Templates without catalog metadata are always
+// included.
+type PromptSet struct {
+	Names    []string `yaml:"names,omitempty"`
+	Tags     []string `yaml:"tags,omitempty"`
+	Stages   []string `yaml:"stages,omitempty"`
+	Products []string `yaml:"products,omitempty"`
+}
+
+// SeedFile is a starter file written into a new project. Its path and
+// content are text/template templates rendered with SeedData.
+type SeedFile struct {
+	Path    string `yaml:"path"`
+	Content string `yaml:"content,omitempty"`
+	// Template names a seed template used instead of Content
+	Template string `yaml:"template,omitempty"`
+}
+
+// IsEmpty reports whether the set selects every prompt
+func (s PromptSet) IsEmpty() bool {
+	return len(s.Names) == 0 && len(s.Tags) == 0 && len(s.Stages) == 0 && len(s.Products) == 0
+}
+
+// Includes reports whether a prompt belongs to the set, given its filename
+// and its catalog tags, stages and product lines
+func (s PromptSet) Includes(filename string, tags, stages, products []string) bool {
+	if s.IsEmpty() {
+		return true
+	}
+
+	for _, name := range s.Names {
+		if strings.EqualFold(name, filename) || strings.EqualFold(name+".md", filename) {
+			return true
+		}
+	}
+
+	if len(tags) == 0 && len(stages) == 0 && len(products) == 0 {
+		return true
+	}
+
+	if len(s.Products) > 0 && !overlaps(s.Products, products) {
+		return false
+	}
+	if len(s.Stages) > 0 && !overlaps(s.Stages, stages) {
+		return false
+	}
+	if len(s.Tags) > 0 && !overlaps(s.Tags, tags) {
+		return false
+	}
+	return len(s.Products) > 0 || len(s.Stages) > 0 || len(s.Tags) > 0
+}
+
+// ProfileDirs returns the directories searched for profiles, in precedence
+// order
+func ProfileDirs() []string {
+	var dirs []string
+	for _, dir := range config.OverrideDirs() {
+		dirs = append(dirs, filepath.Join(dir, ProfilesDir))
+	}
+	for _, source := range config.TemplateSources() {
+		dirs = append(dirs, filepath.Join(source, ProfilesDir))
+	}
+	return dirs
+}
+
+// LoadProfile finds a profile by name in the profile directories, falling
+// back to the built-in profiles
+func LoadProfile(name string) (*Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return nil, fmt.Errorf("profile %q not found (run \"now-sc profiles list\")", name)
+}
+
+// ListProfiles returns every available profile. A profile defined in a more
+// specific location hides built-in and shared profiles of the same name.
+func ListProfiles() ([]*Profile, error) {
+	seen := map[string]bool{}
+	var profiles []*Profile
+
+	add := func(content []byte, source string) error {
+		profile, err := ParseProfile(content)
+		if err != nil {
+			return fmt.Errorf("invalid profile %s: %w", source, err)
+		}
+		if seen[profile.Name] {
+			return nil
+		}
+		seen[profile.Name] = true
+		profile.Source = source
+		profiles = append(profiles, profile)
+		return nil
+	}
+
+	for _, dir := range ProfileDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !isYAML(entry.Name()) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read profile %s: %w", path, err)
+			}
+			if err := add(content, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	entries, err := builtinProfiles.ReadDir("profiles")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		content, err := builtinProfiles.ReadFile("profiles/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if err := add(content, BuiltinSource); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// ParseProfile decodes and validates a YAML profile
+func ParseProfile(content []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.Unmarshal(content, &profile); err != nil {
+		return nil, err
+	}
+
+	if profile.Name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+	if profile.Layout != nil {
+		if err := profile.Layout.Validate(); err != nil {
+			return nil, fmt.Errorf("layout: %w", err)
+		}
+	}
+	for _, file := range profile.Files {
+		if !isRelativePath(file.Path) {
+			return nil, fmt.Errorf("seed file path %q must stay inside the project", file.Path)
+		}
+	}
+	return &profile, nil
+}
+
+// ResolveLayout returns the profile's layout with its extra directories
+// merged in
+func (p *Profile) ResolveLayout() (*Layout, error) {
+	base := p.Layout
+	if base == nil {
+		var err error
+		if base, err = LoadLayout(); err != nil {
+			return nil, err
+		}
+	}
+
+	layout := &Layout{
+		Version:     base.Version,
+		Directories: mergeDirectories(base.Directories, p.Directories),
+		Source:      base.Source,
+	}
+	if len(p.Directories) > 0 {
+		layout.Source = fmt.Sprintf("%s + profile %s", base.Source, p.Name)
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	return layout, nil
+}
+
+func mergeDirectories(base, extra []Directory) []Directory {
+	merged := make([]Directory, len(base))
+	copy(merged, base)
+
+	for _, dir := range extra {
+		found := false
+		for i := range merged {
+			if merged[i].Name == dir.Name {
+				if dir.Description != "" {
+					merged[i].Description = dir.Description
+				}
+				merged[i].Customers = merged[i].Customers || dir.Customers
+				merged[i].Inbox = merged[i].Inbox || dir.Inbox
+				if dir.Role != "" {
+					merged[i].Role = dir.Role
+				}
+				merged[i].Children = mergeDirectories(merged[i].Children, dir.Children)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, dir)
+		}
+	}
+	return merged
+}
+
+func overlaps(want, have []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(w, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isYAML(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// isRelativePath reports whether a slash-separated path stays inside its root
+func isRelativePath(path string) bool {
+	cleaned := filepath.Clean(filepath.FromSlash(path))
+	return path != "" && !filepath.IsAbs(cleaned) && cleaned != ".." &&
+		!strings.HasPrefix(cleaned, ".."+string(filepath.Separator))
+}
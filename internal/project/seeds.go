@@ -0,0 +1,174 @@
+package project
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// SeedsDir is the folder, next to a profile file, holding its seed templates
+const SeedsDir = "seeds"
+
+//go:embed seeds/*.tmpl
+var builtinSeeds embed.FS
+
+// SeedData is the project and customer data seed templates are rendered with
+type SeedData struct {
+	Project  string
+	Customer Customer
+	Owner    string
+	Stage    string
+	Profile  string
+	// Date is the day the project was created, as YYYY-MM-DD
+	Date string
+	// CustomerDir is the customer's folder relative to the project root
+	CustomerDir string
+	// Structure is the project layout as a Markdown list
+	Structure string
+}
+
+// NewSeedData collects the data seed templates are rendered with
+func NewSeedData(layout *Layout, projectName string, customer Customer, owner, stage, profile string) SeedData {
+	return SeedData{
+		Project:     projectName,
+		Customer:    customer,
+		Owner:       owner,
+		Stage:       stage,
+		Profile:     profile,
+		Date:        time.Now().Format("2006-01-02"),
+		CustomerDir: filepath.ToSlash(filepath.Join(layout.CustomersDir(), customer.Dir)),
+		Structure:   layout.Markdown(customer.Dir),
+	}
+}
+
+var seedFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"title": func(s string) string {
+		r := []rune(s)
+		if len(r) > 0 {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		return string(r)
+	},
+}
+
+// RenderSeed renders a seed template with the project data
+func RenderSeed(name, content string, data SeedData) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(seedFuncs).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render seed template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// BuiltinSeed returns the content of a built-in seed template
+func BuiltinSeed(name string) (string, error) {
+	content, err := builtinSeeds.ReadFile(SeedsDir + "/" + name)
+	if err != nil {
+		return "", fmt.Errorf("seed template %q not found", name)
+	}
+	return string(content), nil
+}
+
+// ProjectFiles returns the README, .env.example, and .gitignore files
+func ProjectFiles(data SeedData) ([]File, error) {
+	seeds := []struct{ path, template string }{
+		{"README.md", "README.md.tmpl"},
+		{".env.example", "env.example.tmpl"},
+		{".gitignore", "gitignore.tmpl"},
+	}
+
+	var files []File
+	for _, seed := range seeds {
+		content, err := BuiltinSeed(seed.template)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := RenderSeed(seed.template, content, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: seed.path, Content: rendered})
+	}
+	return files, nil
+}
+
+// CreateProjectFiles creates the README, .env.example, and .gitignore files,
+// keeping any that already exist
+func CreateProjectFiles(projectPath string, data SeedData) error {
+	files, err := ProjectFiles(data)
+	if err != nil {
+		return err
+	}
+	_, err = WriteFiles(projectPath, files)
+	return err
+}
+
+// SeedFiles renders the profile's starter files. Paths and content are
+// templates; a file naming a template is read from the seeds folder next to
+// the profile, falling back to the built-in seeds.
+func (p *Profile) SeedFiles(data SeedData) ([]File, error) {
+	files := make([]File, 0, len(p.Files))
+	for _, file := range p.Files {
+		name, content := file.Path, file.Content
+		if file.Template != "" {
+			var err error
+			name = file.Template
+			if content, err = p.seedTemplate(file.Template); err != nil {
+				return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+			}
+		}
+
+		path, err := RenderSeed(file.Path, file.Path, data)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+		if !isRelativePath(string(path)) {
+			return nil, fmt.Errorf("profile %s: seed file path %q must stay inside the project", p.Name, path)
+		}
+		rendered, err := RenderSeed(name, content, data)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+		files = append(files, File{Path: string(path), Content: rendered})
+	}
+	return files, nil
+}
+
+// CreateSeedFiles writes the profile's seed files, leaving existing files
+// untouched
+func (p *Profile) CreateSeedFiles(projectPath string, data SeedData) error {
+	files, err := p.SeedFiles(data)
+	if err != nil {
+		return err
+	}
+	_, err = WriteFiles(projectPath, files)
+	return err
+}
+
+func (p *Profile) seedTemplate(name string) (string, error) {
+	if !isRelativePath(name) {
+		return "", fmt.Errorf("seed template %q must be a relative path", name)
+	}
+	if p.Source != "" && p.Source != BuiltinSource {
+		content, err := os.ReadFile(filepath.Join(filepath.Dir(p.Source), SeedsDir, filepath.FromSlash(name)))
+		if err == nil {
+			return string(content), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read seed template %s: %w", name, err)
+		}
+	}
+	return BuiltinSeed(name)
+}
@@ -0,0 +1,176 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Stages are the presales stages a template can be tagged with
+var Stages = []string{"discovery", "demo", "poc", "close"}
+
+// StringList is a YAML value that accepts either a single string or a list
+type StringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		if value.Value != "" {
+			*l = StringList{value.Value}
+		}
+		return nil
+	}
+
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// Contains reports whether the list holds the value, ignoring case
+func (l StringList) Contains(value string) bool {
+	for _, item := range l {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Metadata is the front matter of a prompt template
+type Metadata struct {
+	Title       string     `yaml:"title"`
+	Description string     `yaml:"description"`
+	Tags        StringList `yaml:"tags"`
+	Stage       StringList `yaml:"stage"`
+	Product     StringList `yaml:"product"`
+}
+
+// Template is a prompt template with its catalog metadata
+type Template struct {
+	Filename string
+	Path     string
+	Metadata
+}
+
+// Label returns the template title, falling back to its filename
+func (t Template) Label() string {
+	if t.Title != "" {
+		return t.Title
+	}
+	return DisplayName(t.Filename)
+}
+
+// Filter selects templates by tag, stage and product line. Empty criteria
+// match everything.
+type Filter struct {
+	Tag     string
+	Stage   string
+	Product string
+}
+
+// Match reports whether the template satisfies the filter
+func (f Filter) Match(t Template) bool {
+	if f.Tag != "" && !t.Tags.Contains(f.Tag) {
+		return false
+	}
+	if f.Stage != "" && !t.Stage.Contains(f.Stage) {
+		return false
+	}
+	if f.Product != "" && !t.Product.Contains(f.Product) {
+		return false
+	}
+	return true
+}
+
+// SplitFrontMatter separates a leading YAML front matter block from the
+// template body. The block ends at the first line that is exactly "---", so
+// an empty block is recognized and a longer rule in the body is not. Content
+// without front matter is returned unchanged.
+func SplitFrontMatter(content string) (string, string) {
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return "", content
+	}
+
+	rest := normalized[len("---\n"):]
+	for pos := 0; ; {
+		line, next, found := strings.Cut(rest[pos:], "\n")
+		if strings.TrimRight(line, " \t") == "---" {
+			if !found {
+				next = ""
+			}
+			return strings.TrimSuffix(rest[:pos], "\n"), next
+		}
+		if !found {
+			return "", content
+		}
+		pos += len(line) + 1
+	}
+}
+
+// ParseMetadata reads the front matter metadata of a template
+func ParseMetadata(content string) (Metadata, string, error) {
+	var meta Metadata
+	frontMatter, body := SplitFrontMatter(content)
+	if frontMatter == "" {
+		return meta, body, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(frontMatter), &meta); err != nil {
+		return meta, body, fmt.Errorf("invalid front matter: %w", err)
+	}
+	return meta, body, nil
+}
+
+// LoadCatalog indexes the templates in a templates directory. Templates
+// with invalid front matter are left out and described in skipped.
+func LoadCatalog(templatesDir string) (catalog []Template, skipped []string, err error) {
+	files, err := List(templatesDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, file := range files {
+		path := filepath.Join(templatesDir, file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		meta, _, err := ParseMetadata(string(content))
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", file, err))
+			continue
+		}
+
+		catalog = append(catalog, Template{Filename: file, Path: path, Metadata: meta})
+	}
+
+	sort.Slice(catalog, func(i, j int) bool {
+		return strings.ToLower(catalog[i].Label()) < strings.ToLower(catalog[j].Label())
+	})
+	return catalog, skipped, nil
+}
+
+// FuzzyMatch reports whether all characters of the query appear in order in
+// the text, ignoring case and spaces
+func FuzzyMatch(query, text string) bool {
+	query = strings.ToLower(strings.ReplaceAll(query, " ", ""))
+	text = strings.ToLower(text)
+
+	for _, r := range query {
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(string(r)):]
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		frontMatter string
+		body        string
+	}{
+		{"none", "# Prompt\n", "", "# Prompt\n"},
+		{"block", "---\ntitle: Discovery\n---\n# Prompt\n", "title: Discovery", "# Prompt\n"},
+		{"empty block", "---\n---\n# Prompt\n", "", "# Prompt\n"},
+		{"closing at end", "---\ntitle: Discovery\n---", "title: Discovery", ""},
+		{"closing with trailing spaces", "---\ntitle: A\n---  \nBody", "title: A", "Body"},
+		{"CRLF", "---\r\ntitle: A\r\n---\r\nBody\r\n", "title: A", "Body\n"},
+		{
+			"rule in body",
+			"---\ntitle: A\n---\nIntro\n\n----\n\nMore\n---\n",
+			"title: A",
+			"Intro\n\n----\n\nMore\n---\n",
+		},
+		{"longer rule does not close", "---\ntitle: A\n-----\nBody\n", "", "---\ntitle: A\n-----\nBody\n"},
+		{"indented dashes do not close", "---\ndescription: |\n  ---\n---\nBody", "description: |\n  ---", "Body"},
+		{"unterminated", "---\ntitle: A\nBody\n", "", "---\ntitle: A\nBody\n"},
+		{"rule at start", "----\nBody\n", "", "----\nBody\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontMatter, body := SplitFrontMatter(tt.content)
+			if frontMatter != tt.frontMatter || body != tt.body {
+				t.Errorf("got (%q, %q), want (%q, %q)", frontMatter, body, tt.frontMatter, tt.body)
+			}
+		})
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	meta, body, err := ParseMetadata("---\ntitle: Discovery Call\nstage: discovery\ntags: [itsm, hrsd]\n---\nBody")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Title != "Discovery Call" || !meta.Stage.Contains("Discovery") || len(meta.Tags) != 2 || body != "Body" {
+		t.Errorf("got %+v, %q", meta, body)
+	}
+
+	if _, _, err := ParseMetadata("---\ntitle: [unclosed\n---\nBody"); err == nil {
+		t.Error("expected an error for invalid front matter")
+	}
+}
+
+func TestLoadCatalogSkipsInvalidTemplates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Discovery.md": "---\ntitle: Discovery Call\n---\nBody",
+		"Broken.md":    "---\ntitle: [unclosed\n---\nBody",
+		"Plain.md":     "No front matter",
+		"notes.txt":    "not a template",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catalog, skipped, err := LoadCatalog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var labels []string
+	for _, tmpl := range catalog {
+		labels = append(labels, tmpl.Label())
+	}
+	if got := strings.Join(labels, ", "); got != "Discovery Call, Plain" {
+		t.Errorf("got %s", got)
+	}
+	if len(skipped) != 1 || !strings.HasPrefix(skipped[0], "Broken.md: invalid front matter") {
+		t.Errorf("got skipped %v", skipped)
+	}
+}
@@ -0,0 +1,132 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+)
+
+const (
+	// TemplatesDir is the project folder holding prompt templates
+	TemplatesDir = "10_PromptTemplates"
+	// PartialsDir is the folder, inside a template source, holding partials
+	PartialsDir = "_partials"
+)
+
+var includePattern = regexp.MustCompile(`\{\{\s*include\s+"([^"]+)"\s*\}\}`)
+
+// Renderer expands include directives in prompt templates
+type Renderer struct {
+	// Sources are the directories searched for partials, in order
+	Sources []string
+}
+
+// NewRenderer creates a renderer that resolves partials from the given
+// templates directory followed by the configured template sources
+func NewRenderer(templatesDir string) *Renderer {
+	return &Renderer{
+		Sources: append([]string{templatesDir}, config.TemplateSources()...),
+	}
+}
+
+// RenderFile reads a template file, drops its front matter and expands its
+// includes
+func (r *Renderer) RenderFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	_, body := SplitFrontMatter(string(content))
+	return r.expand(body, []string{absPath(path)})
+}
+
+// Render expands includes in the given template content
+func (r *Renderer) Render(content string) (string, error) {
+	return r.expand(content, nil)
+}
+
+func (r *Renderer) expand(content string, stack []string) (string, error) {
+	var expandErr error
+	result := includePattern.ReplaceAllStringFunc(content, func(match string) string {
+		if expandErr != nil {
+			return match
+		}
+
+		name := includePattern.FindStringSubmatch(match)[1]
+		path, err := r.resolve(name)
+		if err != nil {
+			expandErr = err
+			return match
+		}
+
+		for _, seen := range stack {
+			if seen == path {
+				expandErr = fmt.Errorf("include cycle detected: %s", formatCycle(append(stack, path)))
+				return match
+			}
+		}
+
+		included, err := os.ReadFile(path)
+		if err != nil {
+			expandErr = fmt.Errorf("failed to read partial %s: %w", name, err)
+			return match
+		}
+
+		_, body := SplitFrontMatter(string(included))
+		expanded, err := r.expand(strings.TrimRight(body, "\n"), append(stack, path))
+		if err != nil {
+			expandErr = err
+			return match
+		}
+		return expanded
+	})
+
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return result, nil
+}
+
+// resolve finds the file an include name refers to. A leading "partials/"
+// maps onto the _partials folder of each source.
+func (r *Renderer) resolve(name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid include path %q", name)
+	}
+
+	candidates := []string{filepath.Join(PartialsDir, cleaned), cleaned}
+	if rest, ok := strings.CutPrefix(filepath.ToSlash(cleaned), "partials/"); ok {
+		candidates = append([]string{filepath.Join(PartialsDir, filepath.FromSlash(rest))}, candidates...)
+	}
+
+	for _, source := range r.Sources {
+		for _, candidate := range candidates {
+			path := filepath.Join(source, candidate)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return absPath(path), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("partial %q not found in %s", name, strings.Join(r.Sources, ", "))
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func formatCycle(stack []string) string {
+	names := make([]string, len(stack))
+	for i, path := range stack {
+		names[i] = filepath.Base(path)
+	}
+	return strings.Join(names, " -> ")
+}
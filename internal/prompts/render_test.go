@@ -0,0 +1,142 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+)
+
+// writeFiles creates files under dir from slash paths
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRenderNestedIncludes(t *testing.T) {
+	t.Setenv(config.TemplateSourcesEnv, "")
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Main.md":               "---\ntitle: Main\n---\nStart\n{{ include \"partials/outer.md\" }}\n{{include \"footer.md\"}}\nEnd\n",
+		"_partials/outer.md":    "---\ndescription: Outer\n---\nOuter [{{include \"inner.md\"}}]\n\n",
+		"_partials/inner.md":    "Inner",
+		"_partials/footer.md":   "Footer",
+		"shared/signature.md":   "Signature",
+		"_partials/uses_dir.md": "{{include \"shared/signature.md\"}}",
+	})
+
+	got, err := NewRenderer(dir).RenderFile(filepath.Join(dir, "Main.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Start\nOuter [Inner]\nFooter\nEnd\n"; got != want {
+		t.Errorf("RenderFile = %q, want %q", got, want)
+	}
+
+	// Including the same partial twice is not a cycle
+	got, err = NewRenderer(dir).Render(`{{include "inner.md"}} {{include "inner.md"}} {{include "uses_dir.md"}}`)
+	if err != nil || got != "Inner Inner Signature" {
+		t.Errorf("Render = %q, %v", got, err)
+	}
+}
+
+func TestRenderCycles(t *testing.T) {
+	t.Setenv(config.TemplateSourcesEnv, "")
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Main.md":        "{{include \"a.md\"}}",
+		"_partials/a.md": "A {{include \"b.md\"}}",
+		"_partials/b.md": "B {{include \"partials/a.md\"}}",
+		"Self.md":        "{{include \"Self.md\"}}",
+	})
+	r := NewRenderer(dir)
+
+	_, err := r.RenderFile(filepath.Join(dir, "Main.md"))
+	if err == nil || !strings.Contains(err.Error(), "include cycle detected: Main.md -> a.md -> b.md -> a.md") {
+		t.Errorf("RenderFile = %v, want the cycle through a.md", err)
+	}
+	// The template itself is on the stack by its absolute path, even when
+	// rendered through a relative one
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := filepath.Rel(wd, filepath.Join(dir, "Self.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.RenderFile(rel); err == nil || !strings.Contains(err.Error(), "Self.md -> Self.md") {
+		t.Errorf("RenderFile of a self-include = %v", err)
+	}
+}
+
+func TestRenderMissingPartial(t *testing.T) {
+	t.Setenv(config.TemplateSourcesEnv, "")
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"_partials/outer.md": "{{include \"missing.md\"}}"})
+	r := NewRenderer(dir)
+
+	if _, err := r.Render(`{{include "outer.md"}}`); err == nil || !strings.Contains(err.Error(), `partial "missing.md" not found`) {
+		t.Errorf("Render = %v, want the missing partial named", err)
+	}
+	for _, name := range []string{"../outside.md", "/etc/passwd"} {
+		if _, err := r.Render(`{{include "` + name + `"}}`); err == nil || !strings.Contains(err.Error(), "invalid include path") {
+			t.Errorf("Render of %s = %v, want an invalid path", name, err)
+		}
+	}
+	// A folder is not a partial
+	writeFiles(t, dir, map[string]string{"_partials/folder.md/file.md": "x"})
+	if _, err := r.Render(`{{include "folder.md"}}`); err == nil {
+		t.Error("Render included a folder")
+	}
+}
+
+func TestRenderLookupOrder(t *testing.T) {
+	project, org, team := t.TempDir(), t.TempDir(), t.TempDir()
+	writeFiles(t, project, map[string]string{
+		"_partials/tone.md": "project tone",
+		"greeting.md":       "project greeting",
+		"_partials/both.md": "project partial",
+		"both.md":           "project root",
+	})
+	writeFiles(t, org, map[string]string{
+		"_partials/tone.md":     "org tone",
+		"_partials/greeting.md": "org greeting",
+		"_partials/legal.md":    "org legal",
+	})
+	writeFiles(t, team, map[string]string{
+		"_partials/legal.md": "team legal",
+		"_partials/sig.md":   "team sig",
+	})
+	t.Setenv(config.TemplateSourcesEnv, strings.Join([]string{org, " ", team}, string(os.PathListSeparator)))
+
+	r := NewRenderer(project)
+	if len(r.Sources) != 3 || r.Sources[1] != org || r.Sources[2] != team {
+		t.Fatalf("sources = %q", r.Sources)
+	}
+	tests := map[string]string{
+		// The project's templates come first, then each source in order
+		`{{include "tone.md"}}`:           "project tone",
+		`{{include "partials/legal.md"}}`: "org legal",
+		`{{include "sig.md"}}`:            "team sig",
+		// An earlier source wins even over a later source's _partials
+		`{{include "greeting.md"}}`: "project greeting",
+		// Within a source, _partials comes before the folder itself
+		`{{include "both.md"}}`: "project partial",
+	}
+	for content, want := range tests {
+		if got, err := r.Render(content); err != nil || got != want {
+			t.Errorf("Render(%s) = %q, %v, want %q", content, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// List returns the prompt template filenames in a templates directory
+func List(templatesDir string) ([]string, error) {
+	entries, err := os.ReadDir(templatesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+// Find resolves a template reference to a file path. The reference may be a
+// path, a filename, or a filename without the .md extension.
+func Find(templatesDir, ref string) (string, error) {
+	candidates := []string{
+		ref,
+		filepath.Join(templatesDir, ref),
+		filepath.Join(templatesDir, ref+".md"),
+		filepath.Join(templatesDir, strings.ReplaceAll(ref, " ", "_")+".md"),
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	// Fall back to a case-insensitive match on filename or title
+	if catalog, _, err := LoadCatalog(templatesDir); err == nil {
+		want := strings.TrimSuffix(strings.ReplaceAll(ref, " ", "_"), ".md")
+		for _, t := range catalog {
+			if strings.EqualFold(strings.TrimSuffix(t.Filename, ".md"), want) || strings.EqualFold(t.Title, ref) {
+				return t.Path, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("template %q not found", ref)
+}
+
+// DisplayName converts a template filename into a readable label
+func DisplayName(filename string) string {
+	return strings.TrimSuffix(strings.ReplaceAll(filename, "_", " "), ".md")
+}
@@ -0,0 +1,112 @@
+package prompttest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixtureDirSuffix is appended to a template's base name to form the folder
+// holding its fixtures, e.g. Discovery_Prep.tests/
+const FixtureDirSuffix = ".tests"
+
+// Fixture is a single regression case for a prompt template
+type Fixture struct {
+	Name   string      `yaml:"name"`
+	Input  string      `yaml:"input"`
+	Assert []Assertion `yaml:"assert"`
+
+	// Path is the fixture file the case was loaded from
+	Path string `yaml:"-"`
+}
+
+// Assertion checks a property of a model response. Exactly one field is set.
+type Assertion struct {
+	Contains    string                 `yaml:"contains,omitempty"`
+	NotContains string                 `yaml:"not_contains,omitempty"`
+	Regex       string                 `yaml:"regex,omitempty"`
+	MaxLength   int                    `yaml:"max_length,omitempty"`
+	JSONSchema  map[string]interface{} `yaml:"json_schema,omitempty"`
+	Judge       string                 `yaml:"judge,omitempty"`
+}
+
+// Describe returns a short label for the assertion
+func (a Assertion) Describe() string {
+	switch {
+	case a.Contains != "":
+		return fmt.Sprintf("contains %q", a.Contains)
+	case a.NotContains != "":
+		return fmt.Sprintf("does not contain %q", a.NotContains)
+	case a.Regex != "":
+		return fmt.Sprintf("matches /%s/", a.Regex)
+	case a.MaxLength > 0:
+		return fmt.Sprintf("at most %d characters", a.MaxLength)
+	case a.JSONSchema != nil:
+		return "matches JSON schema"
+	case a.Judge != "":
+		return "judge: " + firstLine(a.Judge)
+	default:
+		return "empty assertion"
+	}
+}
+
+// FixtureDir returns the fixture folder for a template path
+func FixtureDir(templatePath string) string {
+	return strings.TrimSuffix(templatePath, filepath.Ext(templatePath)) + FixtureDirSuffix
+}
+
+// GoldenPath returns the stored golden output path for a fixture
+func (f Fixture) GoldenPath() string {
+	return strings.TrimSuffix(f.Path, filepath.Ext(f.Path)) + ".golden.md"
+}
+
+// LoadFixtures reads all fixtures stored beside a template
+func LoadFixtures(templatePath string) ([]Fixture, error) {
+	dir := FixtureDir(templatePath)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures []Fixture
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture %s: %w", entry.Name(), err)
+		}
+
+		var fixture Fixture
+		if err := yaml.Unmarshal(content, &fixture); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", entry.Name(), err)
+		}
+		if fixture.Name == "" {
+			fixture.Name = strings.TrimSuffix(entry.Name(), ext)
+		}
+		fixture.Path = path
+		fixtures = append(fixtures, fixture)
+	}
+
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].Name < fixtures[j].Name })
+	return fixtures, nil
+}
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
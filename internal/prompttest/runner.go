@@ -0,0 +1,185 @@
+package prompttest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/diff"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+const judgePrompt = `You are grading the output of another AI model against a rubric.
+Read the rubric and the output, then reply with PASS or FAIL on the first line,
+followed by a one-sentence reason on the second line.`
+
+// Runner executes fixtures against a provider
+type Runner struct {
+	Provider provider.Provider
+	// Judge grades rubric assertions. Defaults to Provider when nil.
+	Judge provider.Provider
+	// UpdateGolden stores each output as the new golden file
+	UpdateGolden bool
+}
+
+// AssertionResult is the outcome of a single assertion
+type AssertionResult struct {
+	Assertion Assertion
+	Passed    bool
+	Message   string
+}
+
+// Result is the outcome of running a fixture
+type Result struct {
+	Fixture    Fixture
+	Output     string
+	Assertions []AssertionResult
+	// GoldenDiff is the diff between the stored golden output and this output
+	GoldenDiff string
+	// GoldenUpdated is set when the golden file was written by this run
+	GoldenUpdated bool
+	Err           error
+}
+
+// Passed reports whether the fixture ran and every assertion held
+func (r Result) Passed() bool {
+	if r.Err != nil {
+		return false
+	}
+	for _, a := range r.Assertions {
+		if !a.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesGolden reports whether the output matched the stored golden output,
+// or became it; fixtures without one always match
+func (r Result) MatchesGolden() bool {
+	return r.GoldenDiff == "" || r.GoldenUpdated
+}
+
+// Run executes a fixture against the rendered prompt
+func (r *Runner) Run(promptContent string, fixture Fixture) Result {
+	result := Result{Fixture: fixture}
+
+	output, err := r.Provider.ExecutePrompt(promptContent, fixture.Input)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to execute prompt: %w", err)
+		return result
+	}
+	result.Output = output
+
+	for _, assertion := range fixture.Assert {
+		result.Assertions = append(result.Assertions, r.check(assertion, output))
+	}
+
+	goldenPath := fixture.GoldenPath()
+	if golden, err := os.ReadFile(goldenPath); err == nil {
+		result.GoldenDiff = diff.Unified(string(golden), output, goldenPath, "output")
+	}
+
+	if r.UpdateGolden && (result.GoldenDiff != "" || !fileExists(goldenPath)) {
+		if err := os.WriteFile(goldenPath, []byte(output), 0644); err != nil {
+			result.Err = fmt.Errorf("failed to write golden output: %w", err)
+			return result
+		}
+		result.GoldenUpdated = true
+	}
+
+	return result
+}
+
+func (r *Runner) check(a Assertion, output string) AssertionResult {
+	result := AssertionResult{Assertion: a, Passed: true}
+	fail := func(format string, args ...interface{}) AssertionResult {
+		result.Passed = false
+		result.Message = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	switch {
+	case a.Contains != "":
+		if !strings.Contains(output, a.Contains) {
+			return fail("output does not contain %q", a.Contains)
+		}
+	case a.NotContains != "":
+		if strings.Contains(output, a.NotContains) {
+			return fail("output contains %q", a.NotContains)
+		}
+	case a.Regex != "":
+		re, err := regexp.Compile(a.Regex)
+		if err != nil {
+			return fail("invalid regex: %v", err)
+		}
+		if !re.MatchString(output) {
+			return fail("output does not match /%s/", a.Regex)
+		}
+	case a.MaxLength > 0:
+		if n := len([]rune(output)); n > a.MaxLength {
+			return fail("output is %d characters, limit is %d", n, a.MaxLength)
+		}
+	case a.JSONSchema != nil:
+		if err := ValidateJSON(output, a.JSONSchema); err != nil {
+			return fail("%v", err)
+		}
+	case a.Judge != "":
+		if r.judgeProvider().Name() == provider.Fake {
+			result.Message = "skipped with fake provider"
+			return result
+		}
+		verdict, reason, err := r.judge(a.Judge, output)
+		if err != nil {
+			return fail("judge failed: %v", err)
+		}
+		result.Message = reason
+		if !verdict {
+			return fail("%s", reason)
+		}
+	default:
+		return fail("assertion has no check")
+	}
+
+	return result
+}
+
+func (r *Runner) judgeProvider() provider.Provider {
+	if r.Judge != nil {
+		return r.Judge
+	}
+	return r.Provider
+}
+
+func (r *Runner) judge(rubric, output string) (bool, string, error) {
+	judge := r.judgeProvider()
+	input := fmt.Sprintf("Rubric:\n%s\n\nOutput:\n%s", rubric, output)
+	response, err := judge.ExecutePrompt(judgePrompt, input)
+	if err != nil {
+		return false, "", err
+	}
+
+	lines := strings.SplitN(strings.TrimSpace(response), "\n", 2)
+	verdict := strings.ToUpper(strings.Trim(strings.TrimSpace(lines[0]), "*.:"))
+	reason := ""
+	if len(lines) > 1 {
+		reason = strings.TrimSpace(lines[1])
+	}
+
+	switch {
+	case strings.HasPrefix(verdict, "PASS"):
+		return true, reason, nil
+	case strings.HasPrefix(verdict, "FAIL"):
+		if reason == "" {
+			reason = "judge returned FAIL"
+		}
+		return false, reason, nil
+	}
+	return false, "", fmt.Errorf("unexpected verdict %q", lines[0])
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
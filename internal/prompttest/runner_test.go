@@ -0,0 +1,168 @@
+package prompttest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+// judgeProvider answers every prompt with a fixed verdict
+type judgeProvider struct {
+	verdict string
+	err     error
+}
+
+func (p *judgeProvider) Name() string  { return "judge" }
+func (p *judgeProvider) Model() string { return "judge" }
+
+func (p *judgeProvider) ExecutePrompt(promptContent, userInput string) (string, error) {
+	return p.verdict, p.err
+}
+
+// writeFixture stores a fixture beside a template and loads it back
+func writeFixture(t *testing.T, content string) Fixture {
+	t.Helper()
+	template := filepath.Join(t.TempDir(), "Discovery_Prep.md")
+	dir := FixtureDir(template)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "kickoff.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fixtures, err := LoadFixtures(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) != 1 {
+		t.Fatalf("loaded %d fixtures, want 1", len(fixtures))
+	}
+	return fixtures[0]
+}
+
+func TestRunAssertions(t *testing.T) {
+	runner := &Runner{Provider: &provider.FakeProvider{}}
+	// The fake provider answers with the prompt's first line and the input
+	output := "Discovery prep\n\nAcme kickoff on Tuesday\n"
+
+	tests := []struct {
+		assertion Assertion
+		want      bool
+	}{
+		{Assertion{Contains: "Acme kickoff"}, true},
+		{Assertion{Contains: "Globex"}, false},
+		{Assertion{NotContains: "Globex"}, true},
+		{Assertion{NotContains: "Tuesday"}, false},
+		{Assertion{Regex: `(?m)^Acme \w+`}, true},
+		{Assertion{Regex: `^\d+$`}, false},
+		{Assertion{Regex: `(`}, false},
+		{Assertion{MaxLength: len(output)}, true},
+		{Assertion{MaxLength: 10}, false},
+		{Assertion{JSONSchema: map[string]interface{}{"type": "object"}}, false},
+		{Assertion{}, false},
+	}
+	for _, tt := range tests {
+		result := runner.Run("Discovery prep\nAsk good questions.", Fixture{Input: "Acme kickoff on Tuesday", Assert: []Assertion{tt.assertion}})
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+		if result.Output != output {
+			t.Fatalf("output = %q", result.Output)
+		}
+		if got := result.Assertions[0].Passed; got != tt.want {
+			t.Errorf("%s: passed = %v, want %v (%s)", tt.assertion.Describe(), got, tt.want, result.Assertions[0].Message)
+		}
+		if result.Passed() != tt.want {
+			t.Errorf("%s: result passed = %v, want %v", tt.assertion.Describe(), result.Passed(), tt.want)
+		}
+	}
+}
+
+func TestRunJudge(t *testing.T) {
+	fixture := Fixture{Input: "Acme", Assert: []Assertion{{Judge: "Mentions the customer"}}}
+
+	// The fake provider cannot grade, so judge assertions are skipped
+	result := (&Runner{Provider: &provider.FakeProvider{}}).Run("Prompt", fixture)
+	if !result.Passed() || result.Assertions[0].Message != "skipped with fake provider" {
+		t.Errorf("judge with the fake provider = %+v", result.Assertions[0])
+	}
+
+	tests := []struct {
+		judge   *judgeProvider
+		passed  bool
+		message string
+	}{
+		{&judgeProvider{verdict: "**PASS**\nNames Acme."}, true, "Names Acme."},
+		{&judgeProvider{verdict: "FAIL\nNo customer."}, false, "No customer."},
+		{&judgeProvider{verdict: "FAIL"}, false, "judge returned FAIL"},
+		{&judgeProvider{verdict: "Maybe"}, false, `judge failed: unexpected verdict "Maybe"`},
+		{&judgeProvider{err: errors.New("offline")}, false, "judge failed: offline"},
+	}
+	for _, tt := range tests {
+		result := (&Runner{Provider: &provider.FakeProvider{}, Judge: tt.judge}).Run("Prompt", fixture)
+		a := result.Assertions[0]
+		if a.Passed != tt.passed || a.Message != tt.message {
+			t.Errorf("judge %q: passed %v, message %q, want %v and %q", tt.judge.verdict, a.Passed, a.Message, tt.passed, tt.message)
+		}
+	}
+}
+
+func TestRunGolden(t *testing.T) {
+	fixture := writeFixture(t, "input: Acme kickoff\nassert:\n  - contains: Acme\n")
+	if fixture.Name != "kickoff" || !strings.HasSuffix(fixture.GoldenPath(), filepath.Join("Discovery_Prep.tests", "kickoff.golden.md")) {
+		t.Fatalf("fixture = %+v, golden %s", fixture, fixture.GoldenPath())
+	}
+
+	// Without a golden file there is nothing to compare
+	result := (&Runner{Provider: &provider.FakeProvider{}}).Run("Prompt", fixture)
+	if result.GoldenDiff != "" || result.GoldenUpdated || !result.MatchesGolden() {
+		t.Errorf("run without a golden file = %+v", result)
+	}
+	if _, err := os.Stat(fixture.GoldenPath()); !os.IsNotExist(err) {
+		t.Fatal("golden file written without --update-golden")
+	}
+
+	update := &Runner{Provider: &provider.FakeProvider{}, UpdateGolden: true}
+	if result = update.Run("Prompt", fixture); !result.GoldenUpdated {
+		t.Fatal("--update-golden did not write the golden file")
+	}
+	golden, err := os.ReadFile(fixture.GoldenPath())
+	if err != nil || string(golden) != result.Output {
+		t.Fatalf("golden = %q, %v, want the output", golden, err)
+	}
+	if result = update.Run("Prompt", fixture); result.GoldenUpdated || result.GoldenDiff != "" {
+		t.Errorf("unchanged output rewrote the golden file: %+v", result)
+	}
+
+	// A changed output fails only with --strict-golden
+	result = (&Runner{Provider: &provider.FakeProvider{}}).Run("Other prompt", fixture)
+	if !strings.Contains(result.GoldenDiff, "+Other prompt") {
+		t.Errorf("golden diff = %q", result.GoldenDiff)
+	}
+	if !result.Passed() || result.MatchesGolden() {
+		t.Errorf("changed output: passed %v, matches golden %v", result.Passed(), result.MatchesGolden())
+	}
+	if result = update.Run("Other prompt", fixture); !result.GoldenUpdated || !result.MatchesGolden() {
+		t.Errorf("--update-golden did not accept the changed output: %+v", result)
+	}
+}
+
+func TestLoadFixturesInvalid(t *testing.T) {
+	template := filepath.Join(t.TempDir(), "Discovery_Prep.md")
+	if fixtures, err := LoadFixtures(template); err != nil || fixtures != nil {
+		t.Errorf("LoadFixtures without fixtures = %v, %v", fixtures, err)
+	}
+	if err := os.MkdirAll(FixtureDir(template), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(FixtureDir(template), "bad.yaml"), []byte("assert: [unclosed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFixtures(template); err == nil || !strings.Contains(err.Error(), "bad.yaml") {
+		t.Errorf("LoadFixtures = %v, want an error naming the fixture", err)
+	}
+}
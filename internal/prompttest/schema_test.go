@@ -0,0 +1,68 @@
+package prompttest
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testSchema = `
+type: object
+required: [title, tags]
+additionalProperties: false
+properties:
+  title:
+    type: string
+    minLength: 3
+    maxLength: 20
+  priority:
+    type: integer
+    enum: [1, 2, 3]
+  tags:
+    type: array
+    minItems: 1
+    maxItems: 2
+    items:
+      type: string
+  owner:
+    type: [string, "null"]
+`
+
+func TestValidateJSON(t *testing.T) {
+	var schema map[string]interface{}
+	if err := yaml.Unmarshal([]byte(testSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		// wantErr is part of the expected error, empty when valid
+		wantErr string
+	}{
+		{"valid", `{"title": "Kickoff", "priority": 2, "tags": ["call"], "owner": null}`, ""},
+		{"code fence", "```json\n{\"title\": \"Kickoff\", \"tags\": [\"call\"]}\n```", ""},
+		{"not JSON", `Sure! Here it is.`, "not valid JSON"},
+		{"wrong type", `["Kickoff"]`, "$: expected object, got array"},
+		{"missing required", `{"title": "Kickoff"}`, `missing required property "tags"`},
+		{"unexpected property", `{"title": "Kickoff", "tags": ["a"], "extra": 1}`, `unexpected property "extra"`},
+		{"too short", `{"title": "Hi", "tags": ["a"]}`, "$.title: expected at least 3 characters"},
+		{"too long", `{"title": "A very long title indeed", "tags": ["a"]}`, "$.title: expected at most 20 characters"},
+		{"not an integer", `{"title": "Kickoff", "priority": 1.5, "tags": ["a"]}`, "$.priority: expected integer"},
+		{"not in enum", `{"title": "Kickoff", "priority": 4, "tags": ["a"]}`, "$.priority: value 4 is not one of"},
+		{"too few items", `{"title": "Kickoff", "tags": []}`, "$.tags: expected at least 1 items"},
+		{"too many items", `{"title": "Kickoff", "tags": ["a", "b", "c"]}`, "$.tags: expected at most 2 items"},
+		{"wrong item type", `{"title": "Kickoff", "tags": ["a", 2]}`, "$.tags[1]: expected string, got number"},
+		{"type list", `{"title": "Kickoff", "tags": ["a"], "owner": 3}`, "$.owner: expected string or null, got number"},
+	}
+	for _, tt := range tests {
+		err := ValidateJSON(tt.response, schema)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: ValidateJSON = %v, want valid", tt.name, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: ValidateJSON = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/Now-AI-Foundry/Now-SC/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+// Provenance records how a saved output was generated, as its YAML front
+// matter
+type Provenance struct {
+	Title      string    `yaml:"title"`
+	Generated  time.Time `yaml:"generated"`
+	Author     string    `yaml:"author,omitempty"`
+	CLIVersion string    `yaml:"cli_version"`
+	Template   Template  `yaml:"template"`
+	Provider   string    `yaml:"provider"`
+	// Model is the model that produced the output
+	Model      string     `yaml:"model"`
+	Parameters Parameters `yaml:"parameters"`
+	Inputs     []Input    `yaml:"inputs,omitempty"`
+	Usage      *Usage     `yaml:"usage,omitempty"`
+	// RegeneratedFrom is the output this one is a new version of
+	RegeneratedFrom string `yaml:"regenerated_from,omitempty"`
+}
+
+// Template is the prompt template an output was generated from
+type Template struct {
+	// Path is relative to the project root; built-in prompts have a name in
+	// angle brackets, e.g. <summary>
+	Path string `yaml:"path"`
+	// Hash is the SHA-256 of the template file
+	Hash string `yaml:"sha256,omitempty"`
+	// RenderedHash is the SHA-256 of the prompt sent, after includes were
+	// expanded
+	RenderedHash string `yaml:"rendered_sha256"`
+}
+
+// Parameters are the values an output was generated with
+type Parameters struct {
+	// Input is what the user entered for the prompt
+	Input string `yaml:"input,omitempty"`
+	// Workflow is the watch workflow that produced the output
+	Workflow string `yaml:"workflow,omitempty"`
+}
+
+// Input is a file sent to the model as context
+type Input struct {
+	// Path is relative to the project root when inside it
+	Path string `yaml:"path"`
+	Hash string `yaml:"sha256"`
+}
+
+// Usage is the number of tokens the generation consumed
+type Usage struct {
+	PromptTokens     int `yaml:"prompt_tokens"`
+	CompletionTokens int `yaml:"completion_tokens"`
+	TotalTokens      int `yaml:"total_tokens"`
+}
+
+// New records a completion generated now by the current user; the caller
+// fills in the template, parameters and inputs
+func New(m *project.Manifest, title, providerName string, c *provider.Completion) *Provenance {
+	author := project.DefaultOwner()
+	if author == "" {
+		author = m.Owner
+	}
+	return &Provenance{
+		Title:      title,
+		Generated:  time.Now().UTC().Truncate(time.Second),
+		Author:     author,
+		CLIVersion: version.Version,
+		Provider:   providerName,
+		Model:      c.Model,
+		Usage:      NewUsage(c.Usage),
+	}
+}
+
+// NewUsage converts the usage reported with a completion, which is nil when
+// the provider reported none
+func NewUsage(u provider.Usage) *Usage {
+	if u == (provider.Usage{}) {
+		return nil
+	}
+	return &Usage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens, TotalTokens: u.TotalTokens}
+}
+
+// Document renders an output with its provenance as front matter
+func (p *Provenance) Document(body string) ([]byte, error) {
+	meta, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode provenance: %w", err)
+	}
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	b.Write(meta)
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimLeft(body, "\n"))
+	return b.Bytes(), nil
+}
+
+// Body lays out a response as the body of an output: prompt outputs repeat
+// the user input before the response, workflow outputs have the response
+// alone
+func (p *Provenance) Body(response string) string {
+	response = strings.TrimSpace(response)
+	if p.Parameters.Workflow != "" {
+		return fmt.Sprintf("# %s\n\n%s\n", p.Title, response)
+	}
+	return fmt.Sprintf("# %s\n\n## User Input\n\n%s\n\n## Response\n\n%s\n", p.Title, p.Parameters.Input, response)
+}
+
+// Parse splits an output into its provenance and body. Outputs without
+// provenance front matter are an error.
+func Parse(content []byte) (*Provenance, string, error) {
+	frontMatter, body := prompts.SplitFrontMatter(string(content))
+	if frontMatter == "" {
+		return nil, "", fmt.Errorf("no provenance front matter")
+	}
+	p := &Provenance{}
+	if err := yaml.Unmarshal([]byte(frontMatter), p); err != nil {
+		return nil, "", fmt.Errorf("invalid provenance front matter: %w", err)
+	}
+	if p.Template.Path == "" {
+		return nil, "", fmt.Errorf("front matter has no template provenance")
+	}
+	return p, strings.TrimLeft(body, "\n"), nil
+}
+
+// ReadFile reads the provenance and body of a saved output
+func ReadFile(path string) (*Provenance, string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	p, body, err := Parse(content)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+	return p, body, nil
+}
+
+// Hash returns the SHA-256 of content
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashFile returns the SHA-256 of a file
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RelPath returns a path relative to the project root as a slash path, or
+// the absolute path when it is outside the project
+func RelPath(root, path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	if absRoot, err := filepath.Abs(root); err == nil {
+		if rel, err := filepath.Rel(absRoot, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(abs)
+}
+
+// Resolve returns the file system path of a path recorded by RelPath
+func Resolve(root, path string) string {
+	if filepath.IsAbs(filepath.FromSlash(path)) {
+		return filepath.FromSlash(path)
+	}
+	return filepath.Join(root, filepath.FromSlash(path))
+}
+
+// BuiltinTemplate records a prompt built into the CLI, e.g. "summary"
+func BuiltinTemplate(name, rendered string) Template {
+	return Template{Path: "<" + name + ">", RenderedHash: Hash([]byte(rendered))}
+}
+
+// IsBuiltin reports whether the template is built into the CLI rather than a
+// file
+func (t Template) IsBuiltin() bool {
+	return strings.HasPrefix(t.Path, "<") && strings.HasSuffix(t.Path, ">")
+}
+
+// NewTemplate records the template file a prompt was rendered from
+func NewTemplate(root, path, rendered string) (Template, error) {
+	hash, err := HashFile(path)
+	if err != nil {
+		return Template{}, err
+	}
+	return Template{Path: RelPath(root, path), Hash: hash, RenderedHash: Hash([]byte(rendered))}, nil
+}
+
+// NewInputs records the files sent to the model as context
+func NewInputs(root string, files []string) ([]Input, error) {
+	var inputs []Input
+	for _, file := range files {
+		hash, err := HashFile(file)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, Input{Path: RelPath(root, file), Hash: hash})
+	}
+	return inputs, nil
+}
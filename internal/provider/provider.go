@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/openrouter"
+)
+
+const (
+	// OpenRouter executes prompts through the OpenRouter API
+	OpenRouter = "openrouter"
+	// Fake returns deterministic responses without calling a model
+	Fake = "fake"
+)
+
+// Provider executes a system prompt against a user input
+type Provider interface {
+	Name() string
+	Model() string
+	ExecutePrompt(promptContent, userInput string) (string, error)
+}
+
+// New creates the named provider. An empty model selects the provider default.
+func New(name, model string) (Provider, error) {
+	switch strings.ToLower(name) {
+	case "", OpenRouter:
+		apiKey := os.Getenv("OPENROUTER_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("OPENROUTER_API_KEY not set")
+		}
+		return &openRouterProvider{Client: openrouter.NewClientWithModel(apiKey, model)}, nil
+	case Fake:
+		return &FakeProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q (expected %s or %s)", name, OpenRouter, Fake)
+	}
+}
+
+// StructuredProvider can hold its response to a JSON schema
+type StructuredProvider interface {
+	Provider
+	ExecuteStructured(promptContent, userInput, schemaName string, schema any) (string, error)
+}
+
+// Usage is the number of tokens a response consumed
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Completion is a response with the model that produced it and the tokens
+// it used; Usage is zero when the provider reports none
+type Completion struct {
+	Content string
+	Model   string
+	Usage   Usage
+}
+
+// Completer reports the model and token usage of its responses
+type Completer interface {
+	Complete(promptContent, userInput string) (*Completion, error)
+}
+
+// Complete executes a prompt, with the model and token usage when the
+// provider reports them
+func Complete(p Provider, promptContent, userInput string) (*Completion, error) {
+	if c, ok := p.(Completer); ok {
+		return c.Complete(promptContent, userInput)
+	}
+	content, err := p.ExecutePrompt(promptContent, userInput)
+	if err != nil {
+		return nil, err
+	}
+	return &Completion{Content: content, Model: p.Model()}, nil
+}
+
+type openRouterProvider struct {
+	*openrouter.Client
+}
+
+func (p *openRouterProvider) Name() string {
+	return OpenRouter
+}
+
+// Complete implements Completer, converting the OpenRouter response
+func (p *openRouterProvider) Complete(promptContent, userInput string) (*Completion, error) {
+	c, err := p.Client.Complete(promptContent, userInput)
+	if err != nil {
+		return nil, err
+	}
+	return &Completion{
+		Content: c.Content,
+		Model:   c.Model,
+		Usage: Usage{
+			PromptTokens:     c.Usage.PromptTokens,
+			CompletionTokens: c.Usage.CompletionTokens,
+			TotalTokens:      c.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+// FakeProvider echoes its input so prompt pipelines can be exercised offline
+type FakeProvider struct{}
+
+// Name implements Provider
+func (p *FakeProvider) Name() string {
+	return Fake
+}
+
+// Model implements Provider
+func (p *FakeProvider) Model() string {
+	return "fake"
+}
+
+// ExecutePrompt returns the first line of the prompt followed by the input
+func (p *FakeProvider) ExecutePrompt(promptContent, userInput string) (string, error) {
+	title := strings.TrimSpace(promptContent)
+	if i := strings.IndexByte(title, '\n'); i >= 0 {
+		title = strings.TrimSpace(title[:i])
+	}
+	return fmt.Sprintf("%s\n\n%s\n", title, strings.TrimSpace(userInput)), nil
+}
+
+// ExecuteStructured returns the smallest response matching the schema: empty
+// strings and arrays, false and zero
+func (p *FakeProvider) ExecuteStructured(promptContent, userInput, schemaName string, schema any) (string, error) {
+	content, err := json.Marshal(emptyInstance(schema))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func emptyInstance(schema any) any {
+	s, _ := schema.(map[string]any)
+	switch s["type"] {
+	case "object":
+		obj := map[string]any{}
+		props, _ := s["properties"].(map[string]any)
+		for name, prop := range props {
+			obj[name] = emptyInstance(prop)
+		}
+		return obj
+	case "array":
+		return []any{}
+	case "boolean":
+		return false
+	case "number", "integer":
+		return 0
+	case "string":
+		return ""
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package provider
+
+import "testing"
+
+// usageProvider reports usage like the OpenRouter provider does
+type usageProvider struct {
+	FakeProvider
+}
+
+func (p *usageProvider) Complete(promptContent, userInput string) (*Completion, error) {
+	return &Completion{Content: "ok", Model: "vendor/model", Usage: Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}}, nil
+}
+
+func TestComplete(t *testing.T) {
+	c, err := Complete(&FakeProvider{}, "Title\nrest", "input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Content != "Title\n\ninput\n" || c.Model != "fake" || c.Usage != (Usage{}) {
+		t.Errorf("got %+v", c)
+	}
+
+	c, err = Complete(&usageProvider{}, "prompt", "input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Model != "vendor/model" || c.Usage.TotalTokens != 5 {
+		t.Errorf("got %+v", c)
+	}
+}
+
+func TestOpenRouterProviderIsCompleter(t *testing.T) {
+	var p Provider = &openRouterProvider{}
+	if _, ok := p.(Completer); !ok {
+		t.Error("the OpenRouter provider does not report usage")
+	}
+}
@@ -0,0 +1,212 @@
+package regenerate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/diff"
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/Now-AI-Foundry/Now-SC/internal/watch"
+)
+
+var versionSuffix = regexp.MustCompile(`_v(\d+)$`)
+
+// Options change what an output is regenerated with
+type Options struct {
+	// Provider and Model replace the recorded ones when set
+	Provider string
+	Model    string
+	// Inputs replace the recorded input files when set
+	Inputs []string
+	// Updated allows a template or inputs that changed since the output was
+	// generated
+	Updated bool
+}
+
+// ChangedError lists the template and inputs that changed since an output
+// was generated, so regenerating it would not be exact
+type ChangedError struct {
+	Files []string
+}
+
+func (e *ChangedError) Error() string {
+	return fmt.Sprintf("changed since the output was generated: %s", strings.Join(e.Files, ", "))
+}
+
+// Result is a regenerated output
+type Result struct {
+	// Output is the new version and Previous the output it regenerated, both
+	// relative to the project root
+	Output     string
+	Previous   string
+	Provenance *provenance.Provenance
+	// Changed lists the template and inputs used in a newer version than
+	// recorded
+	Changed []string
+	// Diff is the unified diff of the body from the previous version
+	Diff string
+}
+
+// Regenerate re-runs the template, inputs and parameters recorded in an
+// output, relative to the project root, and writes the result as the next
+// version alongside it
+func Regenerate(m *project.Manifest, file string, opts Options) (*Result, error) {
+	prov, body, err := provenance.ReadFile(m.Path(file))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Previous: filepath.ToSlash(file)}
+	system, template, err := renderTemplate(m, prov.Template)
+	if err != nil {
+		return nil, err
+	}
+	if template.RenderedHash != prov.Template.RenderedHash {
+		result.Changed = append(result.Changed, prov.Template.Path)
+	}
+
+	files := opts.Inputs
+	if len(files) == 0 {
+		for _, input := range prov.Inputs {
+			path := provenance.Resolve(m.Root(), input.Path)
+			hash, err := provenance.HashFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("input %s: %w", input.Path, err)
+			}
+			if hash != input.Hash {
+				result.Changed = append(result.Changed, input.Path)
+			}
+			files = append(files, path)
+		}
+	}
+	if len(result.Changed) > 0 && !opts.Updated {
+		return nil, &ChangedError{Files: result.Changed}
+	}
+
+	userInput, err := buildInput(m, prov.Parameters, files)
+	if err != nil {
+		return nil, err
+	}
+
+	providerName, model := prov.Provider, prov.Model
+	if opts.Provider != "" {
+		providerName = opts.Provider
+	}
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	p, err := provider.New(providerName, model)
+	if err != nil {
+		return nil, err
+	}
+	completion, err := provider.Complete(p, system, userInput)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := provenance.NewInputs(m.Root(), files)
+	if err != nil {
+		return nil, err
+	}
+	next := provenance.New(m, prov.Title, p.Name(), completion)
+	next.Template = template
+	next.Parameters = prov.Parameters
+	next.Inputs = inputs
+	next.RegeneratedFrom = result.Previous
+	updated := next.Body(completion.Content)
+	document, err := next.Document(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := NextVersion(m.Path(file))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(output, document, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	result.Output = provenance.RelPath(m.Root(), output)
+	result.Provenance = next
+	result.Diff = diff.Unified(body, updated, result.Previous, result.Output)
+	return result, nil
+}
+
+// renderTemplate renders the prompt an output was generated from as it is
+// now
+func renderTemplate(m *project.Manifest, recorded provenance.Template) (string, provenance.Template, error) {
+	if recorded.IsBuiltin() {
+		name := strings.Trim(recorded.Path, "<>")
+		system, ok := watch.BuiltinPrompt(name)
+		if !ok {
+			return "", provenance.Template{}, fmt.Errorf("unknown built-in template %s", recorded.Path)
+		}
+		return system, provenance.BuiltinTemplate(name, system), nil
+	}
+
+	path := provenance.Resolve(m.Root(), recorded.Path)
+	system, err := prompts.NewRenderer(m.Path(prompts.TemplatesDir)).RenderFile(path)
+	if err != nil {
+		return "", provenance.Template{}, fmt.Errorf("template %s: %w", recorded.Path, err)
+	}
+	template, err := provenance.NewTemplate(m.Root(), path, system)
+	if err != nil {
+		return "", provenance.Template{}, err
+	}
+	return system, template, nil
+}
+
+// buildInput rebuilds what was sent to the model: the source file of a watch
+// workflow, or the user input with its attachments
+func buildInput(m *project.Manifest, params provenance.Parameters, files []string) (string, error) {
+	if params.Workflow != "" {
+		if len(files) != 1 {
+			return "", fmt.Errorf("workflow %s takes one input, got %d", params.Workflow, len(files))
+		}
+		content, err := ingest.Text(files[0])
+		if err != nil {
+			return "", err
+		}
+		return watch.WorkflowInput(provenance.RelPath(m.Root(), files[0]), content), nil
+	}
+
+	attachments, err := ingest.ReadAttachments(files)
+	if err != nil {
+		return "", err
+	}
+	return ingest.WithAttachments(params.Input, attachments), nil
+}
+
+// NextVersion returns the path of the next version of an output:
+// Summary.md, then Summary_v2.md, Summary_v3.md and so on, after the
+// highest version already in its folder
+func NextVersion(path string) (string, error) {
+	dir, ext := filepath.Dir(path), filepath.Ext(path)
+	stem := versionSuffix.ReplaceAllString(strings.TrimSuffix(filepath.Base(path), ext), "")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	latest := 1
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ext || !strings.HasPrefix(name, stem) {
+			continue
+		}
+		if match := versionSuffix.FindStringSubmatch(name); match != nil && name == stem+match[0] {
+			if n, _ := strconv.Atoi(match[1]); n > latest {
+				latest = n
+			}
+		}
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s_v%d%s", stem, latest+1, ext)), nil
+}
@@ -0,0 +1,216 @@
+package regenerate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/Now-AI-Foundry/Now-SC/internal/watch"
+)
+
+func touch(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		file     string
+		want     string
+	}{
+		{"first regeneration", []string{"Summary.md"}, "Summary.md", "Summary_v2.md"},
+		{"from a version", []string{"Summary.md", "Summary_v2.md"}, "Summary_v2.md", "Summary_v3.md"},
+		{"from an older version", []string{"Summary.md", "Summary_v2.md", "Summary_v3.md"}, "Summary.md", "Summary_v4.md"},
+		{"numeric order", []string{"Summary.md", "Summary_v9.md", "Summary_v10.md"}, "Summary_v9.md", "Summary_v11.md"},
+		{"shared prefix", []string{"Summary.md", "Summary_Final.md", "Summary_Final_v5.md", "Summary_v2.txt"}, "Summary.md", "Summary_v2.md"},
+		{"stem with a prefix of another", []string{"Summary.md", "Summary_v4.md", "Summary_Final.md"}, "Summary_Final.md", "Summary_Final_v2.md"},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		touch(t, dir, tt.existing...)
+		got, err := NextVersion(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if filepath.Base(got) != tt.want {
+			t.Errorf("%s: NextVersion(%s) = %s, want %s", tt.name, tt.file, filepath.Base(got), tt.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, m *project.Manifest, rel, content string) string {
+	t.Helper()
+	path := m.Path(filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeOutput saves an output generated by the fake provider with its
+// provenance and returns its path relative to the project root
+func writeOutput(t *testing.T, m *project.Manifest, rel string, template provenance.Template, params provenance.Parameters, inputs ...string) string {
+	t.Helper()
+	prov := provenance.New(m, "Discovery Prep", provider.Fake, &provider.Completion{Model: "fake"})
+	prov.Template = template
+	prov.Parameters = params
+	recorded, err := provenance.NewInputs(m.Root(), inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prov.Inputs = recorded
+	document, err := prov.Document(prov.Body("First response"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, m, rel, string(document))
+	return rel
+}
+
+func newProject(t *testing.T) (*project.Manifest, string, string) {
+	t.Helper()
+	m := project.NewManifest(t.TempDir(), "proj")
+	templatePath := writeFile(t, m, filepath.Join(prompts.TemplatesDir, "Discovery.md"), "Prepare discovery questions\n")
+	inputPath := writeFile(t, m, "00_Inbox/notes/pricing.md", "Pricing notes")
+	return m, templatePath, inputPath
+}
+
+func recordTemplate(t *testing.T, m *project.Manifest, path string) provenance.Template {
+	t.Helper()
+	rendered, err := prompts.NewRenderer(m.Path(prompts.TemplatesDir)).RenderFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := provenance.NewTemplate(m.Root(), path, rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return template
+}
+
+func TestRegenerate(t *testing.T) {
+	m, templatePath, inputPath := newProject(t)
+	output := writeOutput(t, m, "99_Assets/Communications/Discovery.md", recordTemplate(t, m, templatePath),
+		provenance.Parameters{Input: "Acme kickoff"}, inputPath)
+
+	result, err := Regenerate(m, output, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Output != "99_Assets/Communications/Discovery_v2.md" || result.Previous != output || len(result.Changed) != 0 {
+		t.Errorf("result = %+v", result)
+	}
+	prov, body, err := provenance.ReadFile(m.Path(result.Output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prov.RegeneratedFrom != output || prov.Template.Path != "10_PromptTemplates/Discovery.md" ||
+		len(prov.Inputs) != 1 || prov.Inputs[0].Path != "00_Inbox/notes/pricing.md" {
+		t.Errorf("provenance = %+v", prov)
+	}
+	// The fake provider echoes the prompt and the input with its attachments
+	for _, want := range []string{"Prepare discovery questions", "Acme kickoff", "Pricing notes"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body is missing %q:\n%s", want, body)
+		}
+	}
+	if !strings.Contains(result.Diff, "-First response") {
+		t.Errorf("diff = %q", result.Diff)
+	}
+}
+
+func TestRegenerateChanged(t *testing.T) {
+	m, templatePath, inputPath := newProject(t)
+	output := writeOutput(t, m, "99_Assets/Communications/Discovery.md", recordTemplate(t, m, templatePath),
+		provenance.Parameters{Input: "Acme kickoff"}, inputPath)
+	writeFile(t, m, "00_Inbox/notes/pricing.md", "Pricing notes, revised")
+	writeFile(t, m, filepath.Join(prompts.TemplatesDir, "Discovery.md"), "Prepare sharper questions\n")
+
+	_, err := Regenerate(m, output, Options{})
+	var changed *ChangedError
+	if !errors.As(err, &changed) {
+		t.Fatalf("Regenerate = %v, want a ChangedError", err)
+	}
+	if strings.Join(changed.Files, " ") != "10_PromptTemplates/Discovery.md 00_Inbox/notes/pricing.md" {
+		t.Errorf("changed = %q", changed.Files)
+	}
+	if project.FileExists(m.Root(), "99_Assets/Communications/Discovery_v2.md") {
+		t.Error("a new version was written despite the changes")
+	}
+
+	result, err := Regenerate(m, output, Options{Updated: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Changed) != 2 || !strings.Contains(result.Diff, "+Prepare sharper questions") {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestRegenerateInputOverride(t *testing.T) {
+	m, templatePath, inputPath := newProject(t)
+	output := writeOutput(t, m, "99_Assets/Communications/Discovery.md", recordTemplate(t, m, templatePath),
+		provenance.Parameters{Input: "Acme kickoff"}, inputPath)
+	// The recorded input is gone, so only the override can regenerate it
+	if err := os.Remove(inputPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Regenerate(m, output, Options{}); err == nil {
+		t.Fatal("Regenerate accepted a missing input")
+	}
+
+	override := writeFile(t, m, "00_Inbox/notes/pricing_v2.md", "New pricing")
+	result, err := Regenerate(m, output, Options{Inputs: []string{override}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Provenance.Inputs) != 1 || result.Provenance.Inputs[0].Path != "00_Inbox/notes/pricing_v2.md" {
+		t.Errorf("inputs = %+v", result.Provenance.Inputs)
+	}
+	if !strings.Contains(result.Diff, "New pricing") {
+		t.Errorf("diff does not use the override: %q", result.Diff)
+	}
+}
+
+func TestRegenerateBuiltinTemplate(t *testing.T) {
+	m, _, inputPath := newProject(t)
+	system, ok := watch.BuiltinPrompt(watch.SummaryTemplate)
+	if !ok {
+		t.Fatal("no built-in summary prompt")
+	}
+	output := writeOutput(t, m, "99_Assets/Summaries/pricing_summary.md",
+		provenance.BuiltinTemplate(watch.SummaryTemplate, system), provenance.Parameters{Workflow: "summary"}, inputPath)
+
+	result, err := Regenerate(m, output, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Output != "99_Assets/Summaries/pricing_summary_v2.md" || result.Provenance.Template.Path != "<summary>" ||
+		result.Provenance.RegeneratedFrom != output {
+		t.Errorf("result = %+v", result)
+	}
+	if !strings.Contains(result.Diff, "+Source: 00_Inbox/notes/pricing.md") {
+		t.Errorf("workflow input not rebuilt from the source file: %q", result.Diff)
+	}
+
+	unknown := writeOutput(t, m, "99_Assets/Summaries/other.md",
+		provenance.Template{Path: "<nope>"}, provenance.Parameters{Workflow: "summary"}, inputPath)
+	if _, err := Regenerate(m, unknown, Options{}); err == nil || !strings.Contains(err.Error(), "<nope>") {
+		t.Errorf("Regenerate with an unknown built-in template = %v", err)
+	}
+}
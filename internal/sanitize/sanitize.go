@@ -0,0 +1,381 @@
+package sanitize
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of redacted data
+const (
+	KindCustomer = "customer"
+	KindContact  = "contact"
+	KindEmail    = "email"
+	KindPhone    = "phone"
+	KindInstance = "instance"
+)
+
+// Placeholders used for data found by pattern detection
+const (
+	EmailPlaceholder    = "redacted@example.com"
+	PhonePlaceholder    = "555-0100"
+	InstancePlaceholder = "example.service-now.com"
+)
+
+// Entity is a known value to replace wherever it appears
+type Entity struct {
+	Kind        string
+	Value       string
+	Placeholder string
+	// CaseSensitive entities only match with the same case, so a contact's
+	// first name "Grant" leaves the word "grant" alone
+	CaseSensitive bool
+}
+
+// Redaction is a single replacement made in a file
+type Redaction struct {
+	File        string
+	Line        int
+	Kind        string
+	Original    string
+	Placeholder string
+}
+
+// Sanitizer replaces customer data in exported projects and records what it
+// replaced
+type Sanitizer struct {
+	Redactions []Redaction
+	// Skipped lists files left out because they cannot be sanitized
+	Skipped []string
+
+	entities  []Entity
+	customers map[string]project.Customer
+	rules     []rule
+}
+
+type rule struct {
+	kind    string
+	pattern *regexp.Regexp
+	// accept reports whether the match at text[start:end] is redacted, when
+	// set
+	accept func(text string, start, end int) bool
+	// replace returns the replacement for a match, the redacted part of the
+	// match and the placeholder it became
+	replace func(match string) (replacement, original, placeholder string)
+}
+
+var (
+	emailPattern     = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern     = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)[\s.-]?|\b\d{2,4}[\s.-])\d{3,4}[\s.-]\d{3,5}\b`)
+	dottedPhone      = regexp.MustCompile(`^\d{3}\.\d{3}\.\d{4}$`)
+	digitGroupAfter  = regexp.MustCompile(`^[.-]\d`)
+	digitGroupBefore = regexp.MustCompile(`\d[.-]$`)
+	instancePattern  = regexp.MustCompile(`(?i)\b[a-z0-9-]+\.service-now\.com\b`)
+)
+
+// New builds a sanitizer for a project from its manifest and the contacts in
+// its customer profiles
+func New(m *project.Manifest, layout *project.Layout) *Sanitizer {
+	s := &Sanitizer{customers: map[string]project.Customer{}}
+
+	contacts := 0
+	for i, c := range m.Customers {
+		placeholder := "Customer " + letters(i)
+		s.add(KindCustomer, c.Name, placeholder)
+		s.add(KindCustomer, c.Dir, placeholder)
+		s.customers[strings.ToLower(c.Dir)] = project.Customer{Name: placeholder, Dir: slug(placeholder)}
+
+		profile, err := project.LoadCustomerProfile(m.CustomerPath(layout, c))
+		if err != nil {
+			continue
+		}
+		s.add(KindCustomer, profile.Name, placeholder)
+		for _, contact := range profile.Contacts {
+			if contact.Name == "" {
+				continue
+			}
+			contacts++
+			contactPlaceholder := fmt.Sprintf("Contact %d", contacts)
+			s.add(KindContact, contact.Name, contactPlaceholder)
+			// Single name parts only match as written, so ordinary words that
+			// happen to be names are left alone
+			for _, part := range strings.Fields(contact.Name) {
+				if utf8.RuneCountInString(part) >= 3 {
+					s.addCaseSensitive(KindContact, part, contactPlaceholder)
+				}
+			}
+			s.add(KindEmail, contact.Email, fmt.Sprintf("contact%d@example.com", contacts))
+			s.add(KindPhone, contact.Phone, PhonePlaceholder)
+		}
+	}
+	s.build()
+	return s
+}
+
+// build turns the entities into rules
+func (s *Sanitizer) build() {
+	// Longest values first, so "Acme Europe" wins over "Acme"
+	sort.SliceStable(s.entities, func(i, j int) bool {
+		return len(s.entities[i].Value) > len(s.entities[j].Value)
+	})
+
+	// Addresses go first so names inside them do not break detection, then
+	// names, then phone numbers
+	var emails, names []rule
+	for _, e := range s.entities {
+		if e.Kind == KindEmail {
+			emails = append(emails, entityRule(e))
+		} else {
+			names = append(names, entityRule(e))
+		}
+	}
+	s.rules = append(s.rules, emails...)
+	s.rules = append(s.rules,
+		rule{kind: KindEmail, pattern: emailPattern, replace: func(match string) (string, string, string) {
+			if strings.HasSuffix(strings.ToLower(match), "@example.com") {
+				return match, match, match
+			}
+			return EmailPlaceholder, match, EmailPlaceholder
+		}},
+		rule{kind: KindInstance, pattern: instancePattern, replace: func(match string) (string, string, string) {
+			if strings.EqualFold(match, InstancePlaceholder) {
+				return match, match, match
+			}
+			return InstancePlaceholder, match, InstancePlaceholder
+		}},
+	)
+	s.rules = append(s.rules, names...)
+	s.rules = append(s.rules, rule{kind: KindPhone, pattern: phonePattern, accept: phoneAccepted, replace: func(match string) (string, string, string) {
+		return PhonePlaceholder, match, PhonePlaceholder
+	}})
+}
+
+// entityRule matches a known value whole, bounded by anything but letters and
+// digits, so "Acme" matches "Acme_Notes.md" but not "Acmeville"
+func entityRule(e Entity) rule {
+	expr := regexp.QuoteMeta(e.Value)
+	if !e.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+	return rule{
+		kind:    e.Kind,
+		pattern: regexp.MustCompile(expr),
+		accept: func(text string, start, end int) bool {
+			before, _ := utf8.DecodeLastRuneInString(text[:start])
+			after, _ := utf8.DecodeRuneInString(text[end:])
+			return !isWordRune(before) && !isWordRune(after)
+		},
+		replace: func(match string) (string, string, string) {
+			return e.Placeholder, match, e.Placeholder
+		},
+	}
+}
+
+// phoneAccepted rejects digit runs that are part of something else: IP
+// addresses and version numbers continue with another dotted group, and
+// dotted numbers must look like 555.123.4567
+func phoneAccepted(text string, start, end int) bool {
+	if digitGroupBefore.MatchString(text[:start]) || digitGroupAfter.MatchString(text[end:]) {
+		return false
+	}
+	match := text[start:end]
+	if !strings.ContainsAny(match, " -()+") && !dottedPhone.MatchString(match) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func (s *Sanitizer) add(kind, value, placeholder string) {
+	s.addEntity(Entity{Kind: kind, Value: value, Placeholder: placeholder})
+}
+
+func (s *Sanitizer) addCaseSensitive(kind, value, placeholder string) {
+	s.addEntity(Entity{Kind: kind, Value: value, Placeholder: placeholder, CaseSensitive: true})
+}
+
+func (s *Sanitizer) addEntity(e Entity) {
+	value := strings.TrimSpace(e.Value)
+	if value == "" {
+		return
+	}
+	for _, existing := range s.entities {
+		if strings.EqualFold(existing.Value, value) {
+			return
+		}
+	}
+	e.Value = value
+	s.entities = append(s.entities, e)
+}
+
+// Text replaces known entities and detected patterns in s without recording
+// redactions
+func (s *Sanitizer) Text(text string) string {
+	return s.replace("", 0, text, false)
+}
+
+// Path replaces customer names in each segment of a project-relative path.
+// Customer folders become the placeholder's folder name.
+func (s *Sanitizer) Path(rel string) string {
+	parts := strings.Split(rel, "/")
+	for i, part := range parts {
+		if c, ok := s.customers[strings.ToLower(part)]; ok {
+			parts[i] = c.Dir
+			continue
+		}
+		if replaced := s.Text(part); replaced != part {
+			parts[i] = slug(replaced)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// File sanitizes a text file line by line, recording every replacement.
+// Binary files are left out since they cannot be sanitized reliably.
+func (s *Sanitizer) File(rel string, content []byte) ([]byte, bool) {
+	if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		s.Skipped = append(s.Skipped, rel)
+		return nil, false
+	}
+	if path.Base(rel) == project.ManifestFile && path.Dir(rel) == project.MetaDir {
+		if redacted, err := s.manifest(rel, content); err == nil {
+			return redacted, true
+		}
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lines[i] = s.replace(rel, i+1, line, true)
+	}
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+func (s *Sanitizer) replace(file string, line int, text string, record bool) string {
+	for _, r := range s.rules {
+		var b strings.Builder
+		last := 0
+		for _, loc := range r.pattern.FindAllStringIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if r.accept != nil && !r.accept(text, start, end) {
+				continue
+			}
+			match := text[start:end]
+			replacement, original, placeholder := r.replace(match)
+			if replacement == match {
+				continue
+			}
+			if record {
+				s.Redactions = append(s.Redactions, Redaction{
+					File: file, Line: line, Kind: r.kind, Original: original, Placeholder: placeholder,
+				})
+			}
+			b.WriteString(text[last:start])
+			b.WriteString(replacement)
+			last = end
+		}
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+	return text
+}
+
+// manifest rewrites the project manifest structurally so customer folders
+// still match their sanitized paths
+func (s *Sanitizer) manifest(rel string, content []byte) ([]byte, error) {
+	var m project.Manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, err
+	}
+
+	if project := s.Text(m.Project); project != m.Project {
+		s.record(rel, KindCustomer, m.Project, project)
+		m.Project = project
+	}
+	for i, c := range m.Customers {
+		if redacted, ok := s.customers[strings.ToLower(c.Dir)]; ok {
+			s.record(rel, KindCustomer, c.Name, redacted.Name)
+			m.Customers[i] = redacted
+		}
+	}
+	if owner := s.Text(m.Owner); owner != m.Owner {
+		s.record(rel, KindContact, m.Owner, owner)
+		m.Owner = owner
+	}
+	return yaml.Marshal(&m)
+}
+
+func (s *Sanitizer) record(file, kind, original, placeholder string) {
+	s.Redactions = append(s.Redactions, Redaction{File: file, Kind: kind, Original: original, Placeholder: placeholder})
+}
+
+// Counts returns the number of redactions per kind
+func (s *Sanitizer) Counts() map[string]int {
+	counts := map[string]int{}
+	for _, r := range s.Redactions {
+		counts[r.Kind]++
+	}
+	return counts
+}
+
+// letters returns "A", "B", ... "Z", "AA", ... for an index
+func letters(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func slug(name string) string {
+	if s, err := project.Slug(name); err == nil {
+		return s
+	}
+	return name
+}
+
+// Report renders the redactions as Markdown for review before sharing
+func (s *Sanitizer) Report(bundle string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Redaction report: %s\n\n", path.Base(bundle))
+	b.WriteString("This report lists the original values. Keep it private and review it before sharing the bundle.\n\n")
+
+	b.WriteString("## Summary\n\n| Kind | Replacements |\n|---|---|\n")
+	counts := s.Counts()
+	for _, kind := range []string{KindCustomer, KindContact, KindEmail, KindPhone, KindInstance} {
+		fmt.Fprintf(&b, "| %s | %d |\n", kind, counts[kind])
+	}
+
+	if len(s.Skipped) > 0 {
+		b.WriteString("\n## Left out\n\nBinary files cannot be sanitized reliably and are not in the bundle:\n\n")
+		for _, file := range s.Skipped {
+			fmt.Fprintf(&b, "- %s\n", file)
+		}
+	}
+
+	if len(s.Redactions) > 0 {
+		b.WriteString("\n## Replacements\n\n| File | Line | Kind | Original | Placeholder |\n|---|---|---|---|---|\n")
+		for _, r := range s.Redactions {
+			line := ""
+			if r.Line > 0 {
+				line = fmt.Sprint(r.Line)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", r.File, line, r.Kind, escapeCell(r.Original), escapeCell(r.Placeholder))
+		}
+	}
+	return []byte(b.String())
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,93 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+func newTestSanitizer() *Sanitizer {
+	s := &Sanitizer{customers: map[string]project.Customer{}}
+	s.add(KindCustomer, "Acme", "Customer A")
+	s.add(KindCustomer, "Acme Europe", "Customer B")
+	s.add(KindContact, "Grant Miller", "Contact 1")
+	s.addCaseSensitive(KindContact, "Grant", "Contact 1")
+	s.addCaseSensitive(KindContact, "Miller", "Contact 1")
+	s.build()
+	return s
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"customer", "Call with Acme today", "Call with Customer A today"},
+		{"adjacent with space", "Acme Acme", "Customer A Customer A"},
+		{"adjacent with comma", "Acme,Acme", "Customer A,Customer A"},
+		{"repeated at edges", "Acme", "Customer A"},
+		{"case insensitive customer", "ACME and acme", "Customer A and Customer A"},
+		{"inside a word", "Acmeville and AcmeAcme", "Acmeville and AcmeAcme"},
+		{"in file name", "Acme_Notes.md", "Customer A_Notes.md"},
+		{"longest first", "Acme Europe and Acme", "Customer B and Customer A"},
+		{"full contact name", "grant miller joined", "Contact 1 joined"},
+		{"name part as written", "Ask Grant about it", "Ask Contact 1 about it"},
+		{"ordinary word", "We will grant access", "We will grant access"},
+		{"email", "Mail jane@acme-corp.io", "Mail " + EmailPlaceholder},
+		{"placeholder email kept", "Mail redacted@example.com", "Mail redacted@example.com"},
+		{"instance", "See acmeprod.service-now.com", "See " + InstancePlaceholder},
+		{"phone dashes", "Call 555-123-4567 now", "Call " + PhonePlaceholder + " now"},
+		{"phone parentheses", "Call (555) 123-4567", "Call " + PhonePlaceholder},
+		{"phone international", "Call +44 20 7946 0958", "Call " + PhonePlaceholder},
+		{"phone dots", "Call 555.123.4567", "Call " + PhonePlaceholder},
+		{"ip address", "Host 10.200.300.40 is up", "Host 10.200.300.40 is up"},
+		{"ip address short", "Host 192.168.100.1", "Host 192.168.100.1"},
+		{"version", "Release 2.100.200", "Release 2.100.200"},
+		{"date", "Due 2025-01-15", "Due 2025-01-15"},
+	}
+	s := newTestSanitizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Text(tt.in); got != tt.want {
+				t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileRecordsRedactions(t *testing.T) {
+	s := newTestSanitizer()
+	out, ok := s.File("notes.md", []byte("Acme Acme\nGrant said 555-123-4567\n"))
+	if !ok {
+		t.Fatal("text file was skipped")
+	}
+	if want := "Customer A Customer A\nContact 1 said " + PhonePlaceholder + "\n"; string(out) != want {
+		t.Errorf("File() = %q, want %q", out, want)
+	}
+	counts := s.Counts()
+	if counts[KindCustomer] != 2 || counts[KindContact] != 1 || counts[KindPhone] != 1 {
+		t.Errorf("Counts() = %v", counts)
+	}
+	if s.Redactions[0].Line != 1 || s.Redactions[0].Original != "Acme" {
+		t.Errorf("first redaction = %+v", s.Redactions[0])
+	}
+}
+
+func TestFileSkipsBinary(t *testing.T) {
+	s := newTestSanitizer()
+	if _, ok := s.File("logo.png", []byte{0x89, 'P', 'N', 'G', 0}); ok {
+		t.Error("binary file was not skipped")
+	}
+	if len(s.Skipped) != 1 {
+		t.Errorf("Skipped = %v", s.Skipped)
+	}
+}
+
+func TestLetters(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := letters(i); got != want {
+			t.Errorf("letters(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package transcript
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// <v Jane Doe>, <v.loud Jane Doe>
+	voicePattern = regexp.MustCompile(`<v(?:\.[^\s>]+)*\s+([^>]+)>`)
+	tagPattern   = regexp.MustCompile(`<[^>]*>`)
+	// "Jane Doe: text"
+	speakerPrefix = regexp.MustCompile(`^([\p{Lu}][\p{L}\p{M}.'()\- ]{0,40}?):\s+(.+)$`)
+	// Teams: "0:0:0.0 --> 0:0:5.320"
+	rangeLine = regexp.MustCompile(`^(\d{1,2}:\d{1,2}(?::\d{1,2})?(?:[.,]\d+)?)\s*-->\s*\S+`)
+	// Teams: "Jane Doe   0:03" or "Jane Doe 00:01:03"
+	speakerTimeLine = regexp.MustCompile(`^(\p{L}[^\d]{0,60}?)\s+(\d{1,2}:\d{2}(?::\d{2})?)$`)
+	// Zoom: "[Jane Doe] 10:00:01"
+	zoomSpeakerLine = regexp.MustCompile(`^\[([^\]]+)\]\s+(\d{1,2}:\d{2}:\d{2})$`)
+	// Zoom: "00:01:03 Jane Doe: text"
+	zoomChatLine = regexp.MustCompile(`^(\d{1,2}:\d{2}:\d{2})\s+(?:From\s+)?([^:]{1,60}?)\s*:\s+(.+)$`)
+	// A line holding only a speaker name
+	nameLine = regexp.MustCompile(`^\p{Lu}[\p{L}\p{M} .,'()\-]{0,60}$`)
+)
+
+func isVTT(content string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(content, "\uFEFF"), "WEBVTT")
+}
+
+// parseCues parses WebVTT and SRT cues: blocks separated by blank lines,
+// each with a timing line followed by the caption text
+func parseCues(content string) []Turn {
+	content = strings.ReplaceAll(strings.TrimPrefix(content, "\uFEFF"), "\r\n", "\n")
+
+	var turns []Turn
+	for _, block := range strings.Split(content, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			// Header, NOTE, STYLE and REGION blocks
+			continue
+		}
+
+		times := strings.SplitN(lines[timing], "-->", 2)
+		start := parseTimestamp(strings.TrimSpace(times[0]))
+		// The end time may be followed by cue settings
+		var end time.Duration
+		if fields := strings.Fields(times[1]); len(fields) > 0 {
+			end = max(parseTimestamp(fields[0]), 0)
+		}
+		var speaker string
+		var text []string
+		for _, line := range lines[timing+1:] {
+			if m := voicePattern.FindStringSubmatch(line); m != nil && speaker == "" {
+				speaker = strings.TrimSpace(m[1])
+			}
+			line = strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(line, "")))
+			if line != "" {
+				text = append(text, line)
+			}
+		}
+
+		joined := strings.Join(text, " ")
+		if speaker == "" {
+			if m := speakerPrefix.FindStringSubmatch(joined); m != nil {
+				speaker, joined = strings.TrimSpace(m[1]), m[2]
+			} else if n := len(turns); n > 0 {
+				// Captions without a speaker continue the previous one
+				speaker = turns[n-1].Speaker
+			}
+		}
+		turns = append(turns, Turn{Speaker: speaker, Start: start, End: end, Text: joined})
+	}
+	return turns
+}
+
+// parseLines parses line-based exports such as Teams .docx transcripts,
+// where a speaker line, optionally with a timestamp, precedes the text
+func parseLines(lines []string) []Turn {
+	var turns []Turn
+	var current *Turn
+	start := func(speaker string, offset time.Duration) {
+		turns = append(turns, Turn{Speaker: speaker, Start: offset})
+		current = &turns[len(turns)-1]
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+
+		if m := rangeLine.FindStringSubmatch(line); m != nil {
+			offset := parseTimestamp(m[1])
+			// Older Teams exports put the speaker on its own line after
+			// the timing line
+			if i+2 < len(lines) && nameLine.MatchString(strings.TrimSpace(lines[i+1])) && !rangeLine.MatchString(lines[i+2]) {
+				start(strings.TrimSpace(lines[i+1]), offset)
+				i++
+			} else {
+				start(speakerOf(current), offset)
+			}
+			continue
+		}
+		if m := speakerTimeLine.FindStringSubmatch(line); m != nil {
+			start(strings.TrimSpace(m[1]), parseTimestamp(m[2]))
+			continue
+		}
+		if m := speakerPrefix.FindStringSubmatch(line); m != nil {
+			start(strings.TrimSpace(m[1]), -1)
+			current.Text = m[2]
+			continue
+		}
+
+		if current == nil {
+			start("", -1)
+		}
+		current.Text = strings.TrimSpace(current.Text + " " + line)
+	}
+	return turns
+}
+
+// parseText parses plain text exports, reporting whether Zoom patterns were
+// found
+func parseText(content string) ([]Turn, bool) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var turns []Turn
+	var current *Turn
+	zoom := false
+	var rest []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		speakerLine := zoomSpeakerLine.FindStringSubmatch(line)
+		chatLine := zoomChatLine.FindStringSubmatch(line)
+		switch {
+		case speakerLine != nil:
+			zoom = true
+			turns = append(turns, Turn{Speaker: strings.TrimSpace(speakerLine[1]), Start: parseTimestamp(speakerLine[2])})
+			current = &turns[len(turns)-1]
+		case chatLine != nil:
+			zoom = true
+			turns = append(turns, Turn{Speaker: strings.TrimSpace(chatLine[2]), Start: parseTimestamp(chatLine[1]), Text: chatLine[3]})
+			current = &turns[len(turns)-1]
+		case current != nil && line != "":
+			current.Text = strings.TrimSpace(current.Text + " " + line)
+		case current == nil:
+			rest = append(rest, line)
+		}
+	}
+	if zoom {
+		return turns, true
+	}
+	return parseLines(rest), false
+}
+
+func speakerOf(t *Turn) string {
+	if t == nil {
+		return ""
+	}
+	return t.Speaker
+}
+
+// parseTimestamp parses offsets like 01:02:03.456, 02:03,456, 0:0:5.32 and
+// 1:03, returning -1 when the offset cannot be read
+func parseTimestamp(s string) time.Duration {
+	s = strings.ReplaceAll(s, ",", ".")
+	var fraction time.Duration
+	if whole, frac, ok := strings.Cut(s, "."); ok {
+		s = whole
+		if f, err := strconv.ParseFloat("0."+frac, 64); err == nil {
+			fraction = time.Duration(f * float64(time.Second))
+		}
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return -1
+	}
+	var total time.Duration
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return -1
+		}
+		total = total*60 + time.Duration(n)*time.Second
+	}
+	return total + fraction
+}
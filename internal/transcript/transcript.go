@@ -0,0 +1,220 @@
+package transcript
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/extract"
+)
+
+// Formats a transcript can be parsed from
+const (
+	FormatVTT   = "WebVTT"
+	FormatSRT   = "SRT"
+	FormatTeams = "Teams"
+	FormatZoom  = "Zoom"
+	FormatText  = "text"
+)
+
+// Turn is one speaker's uninterrupted contribution
+type Turn struct {
+	Speaker string
+	// Start is the offset from the start of the meeting, or -1 when unknown
+	Start time.Duration
+	// End is when a caption cue ends, or 0 when unknown
+	End  time.Duration
+	Text string
+}
+
+// Transcript is a meeting transcript normalized to speaker turns
+type Transcript struct {
+	Title  string
+	Format string
+	Turns  []Turn
+}
+
+// Extensions lists the file extensions that are always transcripts
+var Extensions = []string{".vtt", ".srt"}
+
+// IsTranscriptFile reports whether a file name looks like a transcript:
+// subtitle files, and documents or text exports named like one
+func IsTranscriptFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+	lower := strings.ToLower(name)
+	return (ext == ".docx" || ext == ".txt") && (strings.Contains(lower, "transcript") || strings.Contains(lower, "recording"))
+}
+
+// ParseFile reads and parses a transcript file
+func ParseFile(path string) (*Transcript, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filepath.Base(path), content)
+}
+
+// Parse parses a transcript, choosing the format from the file name and
+// content, and merges consecutive turns of the same speaker
+func Parse(name string, content []byte) (*Transcript, error) {
+	t := &Transcript{Title: title(name)}
+
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(name)); {
+	case ext == ".docx":
+		var paragraphs []string
+		if paragraphs, err = extract.DocxParagraphs(content); err == nil {
+			t.Format = FormatTeams
+			t.Turns = parseLines(paragraphs)
+		}
+	case ext == ".srt":
+		t.Format = FormatSRT
+		t.Turns = parseCues(string(content))
+	case ext == ".vtt" || isVTT(string(content)):
+		t.Format = FormatVTT
+		t.Turns = parseCues(string(content))
+	case strings.Contains(string(content), "-->"):
+		t.Format = FormatSRT
+		t.Turns = parseCues(string(content))
+	default:
+		var zoom bool
+		t.Turns, zoom = parseText(string(content))
+		t.Format = FormatText
+		if zoom {
+			t.Format = FormatZoom
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transcript %s: %w", name, err)
+	}
+
+	t.Merge()
+	if len(t.Turns) == 0 {
+		return nil, fmt.Errorf("no speaker turns found in %s", name)
+	}
+	return t, nil
+}
+
+// Merge joins consecutive turns of the same speaker and drops text repeated
+// by rolling captions: a cue overlapping the previous one that repeats or
+// extends its text. Repeated speech in separate cues is kept.
+func (t *Transcript) Merge() {
+	var merged []Turn
+	var prev Turn
+	for _, turn := range t.Turns {
+		turn.Text = strings.TrimSpace(turn.Text)
+		if turn.Text == "" {
+			continue
+		}
+		if n := len(merged); n > 0 && merged[n-1].Speaker == turn.Speaker {
+			last := &merged[n-1]
+			switch {
+			case rolling(prev, turn) && turn.Text == prev.Text:
+			case rolling(prev, turn) && strings.HasPrefix(turn.Text, prev.Text):
+				last.Text = strings.TrimSuffix(last.Text, prev.Text) + turn.Text
+			default:
+				last.Text += " " + turn.Text
+			}
+			last.End = turn.End
+			prev = turn
+			continue
+		}
+		merged = append(merged, turn)
+		prev = turn
+	}
+	t.Turns = merged
+}
+
+// rolling reports whether a cue starts before the previous one has ended,
+// as rolling captions do; identical text must overlap it, not just follow
+func rolling(prev, cue Turn) bool {
+	if prev.End <= 0 || cue.Start < 0 {
+		return false
+	}
+	if cue.Text == prev.Text {
+		return cue.Start < prev.End
+	}
+	return cue.Start <= prev.End
+}
+
+// Speakers returns the speakers in order of first appearance
+func (t *Transcript) Speakers() []string {
+	seen := map[string]bool{}
+	var speakers []string
+	for _, turn := range t.Turns {
+		if turn.Speaker != "" && !seen[turn.Speaker] {
+			seen[turn.Speaker] = true
+			speakers = append(speakers, turn.Speaker)
+		}
+	}
+	return speakers
+}
+
+// Duration returns the time from the first to the last timed turn, or 0
+// when unknown. Zoom exports use wall-clock times, so offsets may not start
+// at zero.
+func (t *Transcript) Duration() time.Duration {
+	first, last := time.Duration(-1), time.Duration(-1)
+	for _, turn := range t.Turns {
+		if turn.Start < 0 {
+			continue
+		}
+		if first < 0 {
+			first = turn.Start
+		}
+		last = turn.Start
+	}
+	return last - first
+}
+
+// Markdown renders the transcript as compact Markdown, one paragraph per turn
+func (t *Transcript) Markdown() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Title)
+
+	details := []string{"Source: " + t.Format}
+	if speakers := t.Speakers(); len(speakers) > 0 {
+		details = append(details, "Speakers: "+strings.Join(speakers, ", "))
+	}
+	if d := t.Duration(); d > 0 {
+		details = append(details, "Duration: "+formatOffset(d))
+	}
+	b.WriteString(strings.Join(details, " · ") + "\n")
+
+	for _, turn := range t.Turns {
+		b.WriteString("\n")
+		if turn.Speaker != "" {
+			fmt.Fprintf(&b, "**%s**", turn.Speaker)
+			if turn.Start >= 0 {
+				fmt.Fprintf(&b, " (%s)", formatOffset(turn.Start))
+			}
+			b.WriteString(": ")
+		} else if turn.Start >= 0 {
+			fmt.Fprintf(&b, "(%s) ", formatOffset(turn.Start))
+		}
+		b.WriteString(turn.Text + "\n")
+	}
+	return []byte(b.String())
+}
+
+// MarkdownName returns the file name of a transcript's Markdown version
+func MarkdownName(name string) string {
+	return strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)) + ".md"
+}
+
+func title(name string) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	return strings.Join(strings.FieldsFunc(base, func(r rune) bool { return r == '_' }), " ")
+}
+
+func formatOffset(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
@@ -0,0 +1,211 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// turns renders turns compactly for comparison
+func turns(t *Transcript) string {
+	var lines []string
+	for _, turn := range t.Turns {
+		start := "-"
+		if turn.Start >= 0 {
+			start = formatOffset(turn.Start)
+		}
+		lines = append(lines, start+" "+turn.Speaker+": "+turn.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		format  string
+		want    string
+	}{
+		{
+			name: "WebVTT voices",
+			file: "kickoff.vtt",
+			content: `WEBVTT
+
+NOTE exported by Teams
+
+1
+00:00:01.000 --> 00:00:04.000
+<v Jane Doe>Welcome everyone.</v>
+
+2
+00:00:04.000 --> 00:00:06.500 align:start
+<v Jane Doe>Let's start with the agenda.</v>
+
+3
+00:00:07.000 --> 00:00:09.000
+<v.loud Bob Smith>Thanks &amp; hello.</v>
+`,
+			format: FormatVTT,
+			want: "00:00:01 Jane Doe: Welcome everyone. Let's start with the agenda.\n" +
+				"00:00:07 Bob Smith: Thanks & hello.",
+		},
+		{
+			name:    "WebVTT detected from content",
+			file:    "kickoff.txt",
+			content: "\uFEFFWEBVTT\r\n\r\n00:01.000 --> 00:02.000\r\nJane: Hi\r\n",
+			format:  FormatVTT,
+			want:    "00:00:01 Jane: Hi",
+		},
+		{
+			name: "SRT speaker prefixes",
+			file: "call.srt",
+			content: `1
+00:00:01,000 --> 00:00:03,000
+Jane Doe: Can you hear me?
+
+2
+00:00:03,500 --> 00:00:05,000
+Bob Smith: Yes.
+
+3
+00:00:05,000 --> 00:00:07,000
+<i>Loud and clear.</i>
+`,
+			format: FormatSRT,
+			want: "00:00:01 Jane Doe: Can you hear me?\n" +
+				"00:00:03 Bob Smith: Yes. Loud and clear.",
+		},
+		{
+			name: "rolling captions",
+			file: "auto.vtt",
+			content: `WEBVTT
+
+00:00:00.000 --> 00:00:02.000
+Jane: Thanks for
+
+00:00:01.500 --> 00:00:03.000
+Jane: Thanks for joining
+
+00:00:02.500 --> 00:00:04.000
+Jane: Thanks for joining today.
+
+00:00:03.500 --> 00:00:04.000
+Jane: Thanks for joining today.
+
+00:00:04.000 --> 00:00:06.000
+Jane: Shall we start?
+`,
+			format: FormatVTT,
+			want:   "00:00:00 Jane: Thanks for joining today. Shall we start?",
+		},
+		{
+			name: "repeated speech",
+			file: "call.srt",
+			content: `1
+00:00:01,000 --> 00:00:02,000
+Bob: OK.
+
+2
+00:00:02,000 --> 00:00:03,000
+Bob: OK.
+
+3
+00:00:05,000 --> 00:00:06,000
+Bob: OK. Let's go.
+`,
+			format: FormatSRT,
+			want:   "00:00:01 Bob: OK. OK. OK. Let's go.",
+		},
+		{
+			name: "Zoom",
+			file: "zoom.txt",
+			content: `[Jane Doe] 10:00:01
+Good morning.
+[Bob Smith] 10:00:09
+Morning.
+`,
+			format: FormatZoom,
+			want:   "10:00:01 Jane Doe: Good morning.\n10:00:09 Bob Smith: Morning.",
+		},
+		{
+			name: "Teams text",
+			file: "meeting_transcript.txt",
+			content: `Jane Doe   0:03
+Welcome.
+Bob Smith   1:10
+Thanks,
+glad to be here.
+`,
+			format: FormatText,
+			want:   "00:00:03 Jane Doe: Welcome.\n00:01:10 Bob Smith: Thanks, glad to be here.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := Parse(tt.file, []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tr.Format != tt.format {
+				t.Errorf("format: got %s, want %s", tr.Format, tt.format)
+			}
+			if got := turns(tr); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	if _, err := Parse("empty.vtt", []byte("WEBVTT\n\n")); err == nil {
+		t.Error("expected an error for a transcript without turns")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tr := &Transcript{Turns: []Turn{
+		{Speaker: "Jane", Start: 0, End: 2 * time.Second, Text: "Hello"},
+		// Follows without overlapping: repeated speech
+		{Speaker: "Jane", Start: 3 * time.Second, End: 4 * time.Second, Text: "Hello"},
+		// No timing: never treated as a rolling caption
+		{Speaker: "Jane", Start: -1, Text: "Hello again"},
+		{Speaker: "Bob", Start: 5 * time.Second, Text: " "},
+		{Speaker: "Bob", Start: 6 * time.Second, Text: "Hi"},
+	}}
+	tr.Merge()
+	if got, want := turns(tr), "00:00:00 Jane: Hello Hello Hello again\n00:00:06 Bob: Hi"; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := map[string]time.Duration{
+		"01:02:03.456": time.Hour + 2*time.Minute + 3*time.Second + 456*time.Millisecond,
+		"02:03,500":    2*time.Minute + 3*time.Second + 500*time.Millisecond,
+		"0:0:5.32":     5*time.Second + 320*time.Millisecond,
+		"1:03":         time.Minute + 3*time.Second,
+		"abc":          -1,
+		"5":            -1,
+	}
+	for in, want := range tests {
+		if got := parseTimestamp(in); got != want {
+			t.Errorf("parseTimestamp(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tr, err := Parse("Acme_kickoff.vtt", []byte("WEBVTT\n\n00:00:01.000 --> 00:00:02.000\n<v Jane>Hi</v>\n\n00:01:05.000 --> 00:01:06.000\n<v Bob>Bye</v>\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Acme kickoff\n\nSource: WebVTT · Speakers: Jane, Bob · Duration: 00:01:04\n\n" +
+		"**Jane** (00:00:01): Hi\n\n**Bob** (00:01:05): Bye\n"
+	if got := string(tr.Markdown()); got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+	if got := MarkdownName("calls/Acme_kickoff.vtt"); got != "Acme_kickoff.md" {
+		t.Errorf("MarkdownName: got %s", got)
+	}
+}
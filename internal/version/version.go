@@ -0,0 +1,5 @@
+package version
+
+// Version is the CLI version, overridden at build time with
+// -ldflags "-X github.com/Now-AI-Foundry/Now-SC/internal/version.Version=..."
+var Version = "1.0.0"
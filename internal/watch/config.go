@@ -0,0 +1,135 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFile configures watch mode, inside the project's metadata folder
+	ConfigFile = "watch.yaml"
+	// DefaultDebounce is how long a file must be left alone before it is
+	// processed
+	DefaultDebounce = 3 * time.Second
+)
+
+// Config chooses what happens to files dropped into the inbox
+type Config struct {
+	Debounce time.Duration `yaml:"debounce,omitempty"`
+	// Ingest files new material at the top of the inbox, as "now-sc ingest"
+	// does, before running workflows on it
+	Ingest bool `yaml:"ingest"`
+	// LLM classifies files with the model when ingesting
+	LLM       bool       `yaml:"llm,omitempty"`
+	Provider  string     `yaml:"provider,omitempty"`
+	Model     string     `yaml:"model,omitempty"`
+	Workflows []Workflow `yaml:"workflows"`
+}
+
+// Workflow runs a prompt on every inbox file it matches
+type Workflow struct {
+	Name string `yaml:"name"`
+	// Match lists glob patterns. Patterns with a slash match the path inside
+	// the inbox, others the file name.
+	Match []string `yaml:"match"`
+	// Template is a prompt template in the project's templates folder; empty
+	// uses the built-in summary prompt
+	Template string `yaml:"template,omitempty"`
+	// Output is the folder results are written to, relative to the project
+	// root
+	Output string `yaml:"output"`
+}
+
+// DefaultConfig files new material and summarizes every note, transcript,
+// email thread and document into the layout's summaries folder
+func DefaultConfig(layout *project.Layout) *Config {
+	return &Config{
+		Debounce: DefaultDebounce,
+		Ingest:   true,
+		Workflows: []Workflow{{
+			Name:   "summary",
+			Match:  []string{"*.md", "*.txt", "*.pdf", "*.docx", "*.pptx", "*.xlsx"},
+			Output: filepath.ToSlash(layout.RoleDir(project.RoleSummaries)),
+		}},
+	}
+}
+
+// LoadConfig reads a project's watch configuration, falling back to the
+// default for its layout when it has none
+func LoadConfig(m *project.Manifest, layout *project.Layout) (*Config, error) {
+	file := m.Path(project.MetaDir, ConfigFile)
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return DefaultConfig(layout), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch config: %w", err)
+	}
+
+	c := &Config{}
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("invalid watch config %s: %w", file, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid watch config %s: %w", file, err)
+	}
+	if c.Debounce == 0 {
+		c.Debounce = DefaultDebounce
+	}
+	return c, nil
+}
+
+// Validate checks that every workflow has a name, patterns and an output
+// folder inside the project
+func (c *Config) Validate() error {
+	if c.Debounce < 0 {
+		return fmt.Errorf("debounce must not be negative")
+	}
+	seen := map[string]bool{}
+	for i, w := range c.Workflows {
+		switch {
+		case w.Name == "":
+			return fmt.Errorf("workflow %d has no name", i+1)
+		case seen[w.Name]:
+			return fmt.Errorf("duplicate workflow %q", w.Name)
+		case len(w.Match) == 0:
+			return fmt.Errorf("workflow %q has no match patterns", w.Name)
+		case w.Output == "":
+			return fmt.Errorf("workflow %q has no output folder", w.Name)
+		case path.IsAbs(w.Output) || strings.HasPrefix(path.Clean(w.Output), ".."):
+			return fmt.Errorf("workflow %q output must be inside the project", w.Name)
+		}
+		if _, err := project.Slug(w.Name); err != nil {
+			return fmt.Errorf("workflow name %q has no usable characters", w.Name)
+		}
+		for _, pattern := range w.Match {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("workflow %q: bad pattern %q", w.Name, pattern)
+			}
+		}
+		seen[w.Name] = true
+	}
+	return nil
+}
+
+// Matches reports whether a workflow applies to a file, given as a slash
+// path inside the inbox
+func (w *Workflow) Matches(inboxPath string) bool {
+	for _, pattern := range w.Match {
+		target := path.Base(inboxPath)
+		if strings.Contains(pattern, "/") {
+			target = inboxPath
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// StateFile records which inbox files have been processed, inside the
+// project's metadata folder
+const StateFile = "watch-state.json"
+
+// State tracks processed files by their project-relative path
+type State struct {
+	Files map[string]FileState `json:"files"`
+}
+
+// FileState is the processed version of a file and what came of it
+type FileState struct {
+	Hash      string    `json:"hash"`
+	Processed time.Time `json:"processed"`
+	// Outputs lists the files written for it, relative to the project root
+	Outputs []string `json:"outputs,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// LoadState reads a project's watch state; a missing file is an empty state
+func LoadState(m *project.Manifest) (*State, error) {
+	s := &State{Files: map[string]FileState{}}
+	content, err := os.ReadFile(m.Path(project.MetaDir, StateFile))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch state: %w", err)
+	}
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf("invalid watch state: %w", err)
+	}
+	if s.Files == nil {
+		s.Files = map[string]FileState{}
+	}
+	return s, nil
+}
+
+// Save writes the state to the project's metadata folder
+func (s *State) Save(m *project.Manifest) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.Path(project.MetaDir), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", project.MetaDir, err)
+	}
+	if err := os.WriteFile(m.Path(project.MetaDir, StateFile), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write watch state: %w", err)
+	}
+	return nil
+}
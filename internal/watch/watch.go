@@ -0,0 +1,402 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/extract"
+	"github.com/Now-AI-Foundry/Now-SC/internal/history"
+	"github.com/Now-AI-Foundry/Now-SC/internal/ingest"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provenance"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+	"github.com/fsnotify/fsnotify"
+)
+
+// SummaryTemplate names the built-in prompt used by workflows without a
+// template
+const SummaryTemplate = "summary"
+
+// summaryPrompt is used by workflows without a template
+const summaryPrompt = `You summarize customer engagement material for a ServiceNow solution consultant.
+Write a concise Markdown summary of the document you are given with these sections:
+
+## Summary
+Three to five sentences on what the document is and why it matters.
+
+## Key Points
+
+## Decisions
+
+## Action Items
+One bullet per follow-up, with the owner and due date when they are stated.
+
+## Open Questions
+
+Only use information in the document. Leave a section out when there is nothing for it.`
+
+// Watcher runs ingestion and workflows on files dropped into a project's
+// inbox
+type Watcher struct {
+	Manifest *project.Manifest
+	Layout   *project.Layout
+	Config   *Config
+	// Provider runs the workflows and, with Config.LLM, classifies files
+	Provider provider.Provider
+	// Logf reports what happened to each file
+	Logf func(format string, args ...any)
+
+	state *State
+}
+
+// Scan processes every inbox file that is new or has changed since it was
+// last processed
+func (w *Watcher) Scan() error {
+	var files []string
+	inbox := w.Manifest.Path(w.Layout.InboxDir())
+	err := filepath.WalkDir(inbox, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != inbox && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read inbox: %w", err)
+	}
+	return w.Process(files)
+}
+
+// Run scans the inbox, then processes files as they are added or changed,
+// once they have been left alone for the debounce period, until ctx is
+// cancelled
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watching: %w", err)
+	}
+	defer fw.Close()
+
+	inbox := w.Manifest.Path(w.Layout.InboxDir())
+	if err := watchDirs(fw, inbox); err != nil {
+		return err
+	}
+	if err := w.Scan(); err != nil {
+		return err
+	}
+
+	pending := map[string]bool{}
+	timer := time.NewTimer(w.Config.Debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				// Files may have landed before the folder was watched
+				if err := watchDirs(fw, event.Name); err != nil {
+					w.Logf("✗ %v", err)
+				}
+				filepath.WalkDir(event.Name, func(p string, d fs.DirEntry, err error) error {
+					if err == nil && d.Type().IsRegular() {
+						pending[p] = true
+					}
+					return nil
+				})
+			} else {
+				pending[event.Name] = true
+			}
+			timer.Reset(w.Config.Debounce)
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			w.Logf("✗ watch error: %v", err)
+
+		case <-timer.C:
+			files := make([]string, 0, len(pending))
+			for file := range pending {
+				files = append(files, file)
+			}
+			pending = map[string]bool{}
+			if err := w.Process(files); err != nil {
+				w.Logf("✗ %v", err)
+			}
+		}
+	}
+}
+
+// watchDirs watches a folder and every folder below it, skipping hidden ones
+func watchDirs(fw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if p != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if err := fw.Add(p); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", p, err)
+		}
+		return nil
+	})
+}
+
+// Process ingests and runs workflows on the given files, skipping those
+// already processed in their current version, and saves the state
+func (w *Watcher) Process(files []string) error {
+	if w.state == nil {
+		state, err := LoadState(w.Manifest)
+		if err != nil {
+			return err
+		}
+		w.state = state
+	}
+
+	root, err := filepath.Abs(w.Manifest.Root())
+	if err != nil {
+		return err
+	}
+	var rels []string
+	for _, file := range files {
+		if abs, err := filepath.Abs(file); err == nil {
+			if rel, err := filepath.Rel(root, abs); err == nil {
+				rels = append(rels, rel)
+			}
+		}
+	}
+	sort.Strings(rels)
+
+	for _, file := range rels {
+		w.process(file)
+	}
+	return w.state.Save(w.Manifest)
+}
+
+func (w *Watcher) process(file string) {
+	info, err := os.Stat(w.Manifest.Path(file))
+	if err != nil || !info.Mode().IsRegular() || w.ignored(file) {
+		return
+	}
+	hash, err := provenance.HashFile(w.Manifest.Path(file))
+	if err != nil {
+		w.Logf("✗ %s: %v", filepath.ToSlash(file), err)
+		return
+	}
+	// Files whose last run failed are retried
+	if prev, ok := w.state.Files[filepath.ToSlash(file)]; ok && prev.Hash == hash && prev.Error == "" {
+		return
+	}
+
+	if w.Config.Ingest && filepath.Dir(file) == filepath.Clean(w.Layout.InboxDir()) {
+		w.ingest(file, hash)
+		return
+	}
+	w.runWorkflows(file, hash)
+}
+
+// ignored reports whether a file is left alone: files that are not material
+// of their own and workflow results
+func (w *Watcher) ignored(file string) bool {
+	if ingest.Skip(w.Manifest, w.Layout, file) {
+		return true
+	}
+	for _, wf := range w.Config.Workflows {
+		if rel, err := filepath.Rel(filepath.FromSlash(wf.Output), file); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// ingest files a new file, then runs the workflows on its Markdown version
+// when it was converted, or on the filed original
+func (w *Watcher) ingest(file, hash string) {
+	in := &ingest.Ingester{Manifest: w.Manifest, Layout: w.Layout}
+	if w.Config.LLM {
+		in.Provider = w.Provider
+	}
+	d, err := in.Plan(file)
+	if err == nil {
+		err = in.Apply(d)
+	}
+	if err != nil {
+		w.record(file, FileState{Hash: hash, Error: err.Error()})
+		w.Logf("✗ %s: %v", filepath.ToSlash(file), err)
+		return
+	}
+	w.Logf("→ %s filed as %s", filepath.ToSlash(file), d.Dest)
+
+	var converted []string
+	for _, md := range d.Markdown {
+		if !extract.IsCache(md) {
+			converted = append(converted, filepath.FromSlash(md))
+		}
+	}
+	dest := filepath.FromSlash(d.Dest)
+	if len(converted) == 0 {
+		w.runWorkflows(dest, hash)
+		return
+	}
+	w.record(dest, FileState{Hash: hash})
+	for _, md := range converted {
+		if mdHash, err := provenance.HashFile(w.Manifest.Path(md)); err == nil {
+			w.runWorkflows(md, mdHash)
+		}
+	}
+}
+
+// runWorkflows runs every matching workflow on a file and records the
+// results
+func (w *Watcher) runWorkflows(file, hash string) {
+	inboxPath, _ := filepath.Rel(w.Layout.InboxDir(), file)
+	entry := FileState{Hash: hash}
+	var errs []string
+	for _, wf := range w.Config.Workflows {
+		if !wf.Matches(filepath.ToSlash(inboxPath)) {
+			continue
+		}
+		output, err := w.runWorkflow(wf, file)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", wf.Name, err))
+			w.Logf("✗ %s: %s: %v", filepath.ToSlash(file), wf.Name, err)
+			continue
+		}
+		entry.Outputs = append(entry.Outputs, filepath.ToSlash(output))
+		w.Logf("✓ %s: %s → %s", filepath.ToSlash(file), wf.Name, filepath.ToSlash(output))
+	}
+	entry.Error = strings.Join(errs, "; ")
+	w.record(file, entry)
+}
+
+func (w *Watcher) runWorkflow(wf Workflow, file string) (string, error) {
+	if w.Provider == nil {
+		return "", fmt.Errorf("no provider configured")
+	}
+	content, err := ingest.Text(w.Manifest.Path(file))
+	if err != nil {
+		return "", err
+	}
+
+	system := summaryPrompt
+	var template provenance.Template
+	if wf.Template != "" {
+		templatesDir := w.Manifest.Path(prompts.TemplatesDir)
+		templatePath := filepath.Join(templatesDir, wf.Template)
+		if system, err = prompts.NewRenderer(templatesDir).RenderFile(templatePath); err != nil {
+			return "", err
+		}
+		if template, err = provenance.NewTemplate(w.Manifest.Root(), templatePath, system); err != nil {
+			return "", err
+		}
+	} else {
+		template = provenance.BuiltinTemplate(SummaryTemplate, system)
+	}
+	inputs, err := provenance.NewInputs(w.Manifest.Root(), []string{w.Manifest.Path(file)})
+	if err != nil {
+		return "", err
+	}
+
+	completion, err := provider.Complete(w.Provider, system, WorkflowInput(file, content))
+	if err != nil {
+		return "", err
+	}
+
+	stem := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	output := w.outputPath(wf, file)
+	prov := provenance.New(w.Manifest, strings.ReplaceAll(stem, "_", " ")+" — "+wf.Name, w.Provider.Name(), completion)
+	prov.Template = template
+	prov.Parameters.Workflow = wf.Name
+	prov.Inputs = inputs
+	document, err := prov.Document(prov.Body(completion.Content))
+	if err != nil {
+		return "", err
+	}
+
+	full := w.Manifest.Path(output)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(output), err)
+	}
+	if err := os.WriteFile(full, document, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	if err := history.Record(w.Manifest, history.CommandWatch, full, prov); err != nil {
+		w.Logf("Warning: could not record %s in the history: %v", filepath.ToSlash(output), err)
+	}
+	return output, nil
+}
+
+// outputPath returns where a workflow writes its result for a file: the file
+// name and workflow name in the workflow's output folder. A file processed
+// before keeps its output; otherwise a taken name, e.g. by a file of the same
+// name in another inbox folder, gets a number.
+func (w *Watcher) outputPath(wf Workflow, file string) string {
+	name, _ := project.Slug(wf.Name)
+	stem := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	dir := filepath.FromSlash(wf.Output)
+	base := stem + "_" + name
+
+	candidate := filepath.Join(dir, base+".md")
+	for i := 2; ; i++ {
+		owner := w.outputOwner(filepath.ToSlash(candidate))
+		if owner == filepath.ToSlash(file) {
+			return candidate
+		}
+		if owner == "" && !project.FileExists(w.Manifest.Root(), candidate) {
+			return candidate
+		}
+		candidate = filepath.Join(dir, fmt.Sprintf("%s_%d.md", base, i))
+	}
+}
+
+// outputOwner returns the file whose recorded outputs include output
+func (w *Watcher) outputOwner(output string) string {
+	for file, state := range w.state.Files {
+		for _, o := range state.Outputs {
+			if o == output {
+				return file
+			}
+		}
+	}
+	return ""
+}
+
+// WorkflowInput is the input a workflow sends to the model for an inbox file
+func WorkflowInput(file, content string) string {
+	return fmt.Sprintf("Source: %s\n\n%s", filepath.ToSlash(file), content)
+}
+
+// BuiltinPrompt returns the built-in prompt named by a template provenance
+// path such as "<summary>"
+func BuiltinPrompt(name string) (string, bool) {
+	if name == SummaryTemplate {
+		return summaryPrompt, true
+	}
+	return "", false
+}
+
+func (w *Watcher) record(file string, entry FileState) {
+	entry.Processed = time.Now().UTC().Truncate(time.Second)
+	w.state.Files[filepath.ToSlash(file)] = entry
+}
@@ -0,0 +1,112 @@
+package watch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/provider"
+)
+
+// flakyProvider fails until it is told to succeed
+type flakyProvider struct {
+	provider.FakeProvider
+	fail bool
+}
+
+func (p *flakyProvider) ExecutePrompt(promptContent, userInput string) (string, error) {
+	if p.fail {
+		return "", errors.New("model unavailable")
+	}
+	return p.FakeProvider.ExecutePrompt(promptContent, userInput)
+}
+
+func newWatcher(t *testing.T, p provider.Provider) *Watcher {
+	t.Helper()
+	root := t.TempDir()
+	m := project.NewManifest(root, "proj")
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+	layout := project.DefaultLayout()
+	config := DefaultConfig(layout)
+	config.Ingest = false
+	return &Watcher{
+		Manifest: m,
+		Layout:   layout,
+		Config:   config,
+		Provider: p,
+		Logf:     t.Logf,
+	}
+}
+
+func writeInbox(t *testing.T, w *Watcher, rel, content string) string {
+	t.Helper()
+	path := w.Manifest.Path(w.Layout.InboxDir(), filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScanKeepsOutputsOfSameNamedFilesApart(t *testing.T) {
+	w := newWatcher(t, &provider.FakeProvider{})
+	writeInbox(t, w, "acme/notes.md", "Acme call notes")
+	writeInbox(t, w, "globex/notes.md", "Globex call notes")
+	if err := w.Scan(); err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := map[string]bool{}
+	for file, state := range w.state.Files {
+		if state.Error != "" || len(state.Outputs) != 1 {
+			t.Fatalf("%s: %+v", file, state)
+		}
+		outputs[state.Outputs[0]] = true
+	}
+	want := []string{"99_Assets/Summaries/notes_summary.md", "99_Assets/Summaries/notes_summary_2.md"}
+	for _, output := range want {
+		if !outputs[output] || !project.FileExists(w.Manifest.Root(), output) {
+			t.Errorf("missing output %s, got %v", output, outputs)
+		}
+	}
+
+	// A changed file overwrites its own output
+	writeInbox(t, w, "globex/notes.md", "Globex call notes, updated")
+	if err := w.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(w.state.Files); n != 2 {
+		t.Errorf("got %d files in the state, want 2", n)
+	}
+	if project.FileExists(w.Manifest.Root(), "99_Assets/Summaries/notes_summary_3.md") {
+		t.Error("reprocessing a file wrote a new output")
+	}
+}
+
+func TestScanRetriesFailedFiles(t *testing.T) {
+	p := &flakyProvider{fail: true}
+	w := newWatcher(t, p)
+	writeInbox(t, w, "notes.md", "Call notes")
+	if err := w.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	key := filepath.ToSlash(filepath.Join(w.Layout.InboxDir(), "notes.md"))
+	if state := w.state.Files[key]; state.Error == "" {
+		t.Fatalf("expected an error, got %+v", state)
+	}
+
+	p.fail = false
+	if err := w.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	state := w.state.Files[key]
+	if state.Error != "" || len(state.Outputs) != 1 {
+		t.Fatalf("unchanged failed file was not retried: %+v", state)
+	}
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/actions"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"github.com/Now-AI-Foundry/Now-SC/internal/prompts"
+)
+
+// Summary is the at-a-glance state of a project
+type Summary struct {
+	LastActivity time.Time
+	// OpenActions counts the open items in actions.yaml or, for projects not
+	// tracking actions, unchecked Markdown tasks ("- [ ] ...")
+	OpenActions int
+}
+
+// Summarize walks a project for its latest change and open action items.
+// Prompt templates and now-sc metadata are skipped.
+func Summarize(root string) Summary {
+	var s Summary
+	tracked := actions.Exists(root)
+	if tracked {
+		if m, err := project.LoadManifest(root); err == nil {
+			if list, err := actions.Load(m); err == nil {
+				s.OpenActions = len(list.Open())
+			}
+		}
+	}
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", project.MetaDir, prompts.TemplatesDir, "node_modules":
+				if path != root {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil && info.ModTime().After(s.LastActivity) {
+			s.LastActivity = info.ModTime()
+		}
+		if !tracked && strings.EqualFold(filepath.Ext(path), ".md") {
+			s.OpenActions += countOpenTasks(path)
+		}
+		return nil
+	})
+	return s
+}
+
+func countOpenTasks(path string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "- [ ]") || strings.HasPrefix(line, "* [ ]") {
+			n++
+		}
+	}
+	return n
+}
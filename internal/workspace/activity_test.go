@@ -0,0 +1,60 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/actions"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+func TestSummarize(t *testing.T) {
+	dir := newProject(t, t.TempDir(), "proj")
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour).Truncate(time.Second)
+	files := map[string]time.Time{
+		"notes.md":                        recent,
+		"10_PromptTemplates/Discovery.md": time.Now(),
+		"plan.md":                         old,
+	}
+	contents := map[string]string{
+		"notes.md":                        "- [ ] Send pricing\n- [x] Book demo\n  * [ ] Follow up\n",
+		"10_PromptTemplates/Discovery.md": "- [ ] not a task of the project\n",
+		"plan.md":                         "- [ ] Draft plan\n",
+	}
+	for name, mod := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents[name]), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Chtimes(project.ManifestPath(dir), old, old)
+
+	s := Summarize(dir)
+	if s.OpenActions != 3 || !s.LastActivity.Equal(recent) {
+		t.Errorf("Summarize = %+v, want 3 open tasks and activity at %s", s, recent)
+	}
+
+	// Projects tracking actions count their open items instead
+	m, err := project.LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := &actions.List{}
+	list.Merge([]actions.Item{{Description: "Send pricing"}, {Description: "Book the demo environment"}})
+	list.Done("A2", time.Now())
+	if err := list.Save(m); err != nil {
+		t.Fatal(err)
+	}
+	if s = Summarize(dir); s.OpenActions != 1 {
+		t.Errorf("Summarize with actions.yaml = %d open, want 1", s.OpenActions)
+	}
+}
@@ -0,0 +1,202 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/config"
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// File is the workspace configuration inside the user config directory
+	File = "workspace.yaml"
+	// RootEnv overrides the configured workspace root
+	RootEnv = "NOW_SC_WORKSPACE"
+)
+
+// Workspace is the set of projects a user works on: every project under the
+// workspace root plus projects registered from elsewhere
+type Workspace struct {
+	// Root is scanned for projects one level deep
+	Root     string    `yaml:"root,omitempty"`
+	Projects []Project `yaml:"projects,omitempty"`
+
+	path string
+}
+
+// Project is a registered project
+type Project struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+}
+
+// Entry is a project known to the workspace
+type Entry struct {
+	Name       string
+	Path       string
+	Registered bool
+	// Manifest is nil when the project cannot be loaded; Err says why
+	Manifest *project.Manifest
+	Err      error
+}
+
+// Path returns the workspace configuration file location
+func Path() string {
+	dir := config.UserDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, File)
+}
+
+// Load reads the workspace configuration. A missing file is an empty
+// workspace.
+func Load() (*Workspace, error) {
+	ws := &Workspace{path: Path()}
+	if ws.path != "" {
+		content, err := os.ReadFile(ws.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read workspace: %w", err)
+		}
+		if err := yaml.Unmarshal(content, ws); err != nil {
+			return nil, fmt.Errorf("invalid workspace %s: %w", ws.path, err)
+		}
+	}
+	return ws, nil
+}
+
+// RootDir returns the workspace root, taking $NOW_SC_WORKSPACE over the
+// configured one
+func (w *Workspace) RootDir() string {
+	root := w.Root
+	if env := os.Getenv(RootEnv); env != "" {
+		root = env
+	}
+	if root == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(expandHome(root)); err == nil {
+		return abs
+	}
+	return root
+}
+
+// Save writes the workspace configuration
+func (w *Workspace) Save() error {
+	if w.path == "" {
+		return fmt.Errorf("no user configuration directory to save the workspace in")
+	}
+	content, err := yaml.Marshal(w)
+	if err != nil {
+		return fmt.Errorf("failed to encode workspace: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(w.path), err)
+	}
+	if err := os.WriteFile(w.path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write workspace: %w", err)
+	}
+	return nil
+}
+
+// Register adds a project, or renames it when its path is already registered
+func (w *Workspace) Register(name, path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	for i, p := range w.Projects {
+		if p.Path == abs {
+			w.Projects[i].Name = name
+			return nil
+		}
+	}
+	w.Projects = append(w.Projects, Project{Name: name, Path: abs})
+	return nil
+}
+
+// Unregister removes a registered project by name or path and reports
+// whether it was registered
+func (w *Workspace) Unregister(ref string) bool {
+	for i, p := range w.Projects {
+		if strings.EqualFold(p.Name, ref) || p.Path == ref {
+			w.Projects = append(w.Projects[:i], w.Projects[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns every project in the workspace, sorted by name
+func (w *Workspace) Entries() []Entry {
+	seen := map[string]bool{}
+	var entries []Entry
+
+	for _, p := range w.Projects {
+		seen[p.Path] = true
+		entry := Entry{Name: p.Name, Path: p.Path, Registered: true}
+		entry.Manifest, entry.Err = project.LoadManifest(p.Path)
+		entries = append(entries, entry)
+	}
+
+	if root := w.RootDir(); root != "" {
+		dirs, _ := os.ReadDir(root)
+		for _, dir := range dirs {
+			path := filepath.Join(root, dir.Name())
+			if !dir.IsDir() || seen[path] {
+				continue
+			}
+			if _, err := os.Stat(project.ManifestPath(path)); err != nil {
+				continue
+			}
+			entry := Entry{Name: dir.Name(), Path: path}
+			entry.Manifest, entry.Err = project.LoadManifest(path)
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+	return entries
+}
+
+// Find returns the project matching ref by name, manifest project name or
+// folder name, ignoring case. A path to a project is accepted too.
+func (w *Workspace) Find(ref string) (*Entry, error) {
+	entries := w.Entries()
+	for _, match := range []func(Entry) bool{
+		func(e Entry) bool { return strings.EqualFold(e.Name, ref) },
+		func(e Entry) bool { return e.Manifest != nil && strings.EqualFold(e.Manifest.Project, ref) },
+		func(e Entry) bool { return strings.EqualFold(filepath.Base(e.Path), ref) },
+	} {
+		for i := range entries {
+			if match(entries[i]) {
+				return &entries[i], nil
+			}
+		}
+	}
+
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		if root, err := project.FindRoot(ref); err == nil {
+			entry := Entry{Name: filepath.Base(root), Path: root}
+			entry.Manifest, entry.Err = project.LoadManifest(root)
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("project %q not found in the workspace (see \"now-sc projects list\")", ref)
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
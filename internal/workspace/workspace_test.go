@@ -0,0 +1,150 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Now-AI-Foundry/Now-SC/internal/project"
+)
+
+// isolate points the user configuration at a temporary home
+func isolate(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv(RootEnv, "")
+	return home
+}
+
+func newProject(t *testing.T, dir, name string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := project.NewManifest(dir, name).Save(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadAndSave(t *testing.T) {
+	home := isolate(t)
+	if !strings.HasPrefix(Path(), home) {
+		t.Fatalf("Path() = %s, want it under %s", Path(), home)
+	}
+
+	ws, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Root != "" || len(ws.Projects) != 0 {
+		t.Errorf("missing workspace = %+v, want empty", ws)
+	}
+
+	ws.Root = "~/projects"
+	if err := ws.Register("Acme POC", filepath.Join(home, "elsewhere", "acme")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Root != "~/projects" || len(loaded.Projects) != 1 || loaded.Projects[0].Path != filepath.Join(home, "elsewhere", "acme") {
+		t.Errorf("loaded %+v", loaded)
+	}
+	if got := loaded.RootDir(); got != filepath.Join(home, "projects") {
+		t.Errorf("RootDir = %s, want ~ expanded", got)
+	}
+	t.Setenv(RootEnv, filepath.Join(home, "override"))
+	if got := loaded.RootDir(); got != filepath.Join(home, "override") {
+		t.Errorf("RootDir = %s, want $%s", got, RootEnv)
+	}
+
+	if err := os.WriteFile(Path(), []byte("projects: {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "invalid workspace") {
+		t.Errorf("Load of an invalid file = %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	isolate(t)
+	ws := &Workspace{}
+	dir := t.TempDir()
+	if err := ws.Register("acme", dir); err != nil {
+		t.Fatal(err)
+	}
+	// Registering the same path again renames it rather than adding it twice
+	if err := ws.Register("Acme POC", dir); err != nil {
+		t.Fatal(err)
+	}
+	if len(ws.Projects) != 1 || ws.Projects[0].Name != "Acme POC" {
+		t.Errorf("projects = %+v", ws.Projects)
+	}
+
+	if !ws.Unregister("acme poc") || len(ws.Projects) != 0 {
+		t.Errorf("Unregister by name left %+v", ws.Projects)
+	}
+	ws.Register("acme", dir)
+	if ws.Unregister("globex") || !ws.Unregister(dir) {
+		t.Error("Unregister by path failed or removed the wrong project")
+	}
+}
+
+func TestEntriesAndFind(t *testing.T) {
+	home := isolate(t)
+	root := filepath.Join(home, "projects")
+	newProject(t, filepath.Join(root, "acme-poc"), "Acme POC")
+	newProject(t, filepath.Join(root, "globex"), "Globex Discovery")
+	if err := os.MkdirAll(filepath.Join(root, "not-a-project"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	elsewhere := newProject(t, filepath.Join(home, "clients", "initech"), "Initech")
+	broken := filepath.Join(home, "clients", "gone")
+
+	ws := &Workspace{Root: root}
+	ws.Register("initech", elsewhere)
+	ws.Register("Gone", broken)
+	// A registered project under the root is listed once, by its registered name
+	ws.Register("Acme", filepath.Join(root, "acme-poc"))
+
+	var names []string
+	for _, e := range ws.Entries() {
+		names = append(names, e.Name)
+		if e.Name == "Gone" && (e.Err == nil || e.Manifest != nil) {
+			t.Errorf("missing project loaded: %+v", e)
+		}
+	}
+	if got := strings.Join(names, " "); got != "Acme globex Gone initech" {
+		t.Errorf("Entries = %s", got)
+	}
+
+	tests := map[string]string{
+		"acme":             filepath.Join(root, "acme-poc"),
+		"GLOBEX DISCOVERY": filepath.Join(root, "globex"),
+		"acme-poc":         filepath.Join(root, "acme-poc"),
+		"Initech":          elsewhere,
+		// A path inside a project selects that project
+		filepath.Join(elsewhere, project.MetaDir): elsewhere,
+	}
+	for ref, want := range tests {
+		entry, err := ws.Find(ref)
+		if err != nil {
+			t.Errorf("Find(%q): %v", ref, err)
+			continue
+		}
+		if entry.Path != want {
+			t.Errorf("Find(%q) = %s, want %s", ref, entry.Path, want)
+		}
+	}
+	if _, err := ws.Find("hooli"); err == nil || !strings.Contains(err.Error(), `project "hooli" not found`) {
+		t.Errorf("Find of an unknown project = %v", err)
+	}
+}